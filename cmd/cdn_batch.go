@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/auth"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/output"
+)
+
+// addBatchFlags 为刷新/预热命令添加分批提交相关标志
+// limits 为接口单次请求上限的说明，用于帮助信息
+func addBatchFlags(cmd *cobra.Command, limits string) {
+	cmd.Flags().Int("batch-size", 0, fmt.Sprintf("每批提交的 URL 数量，默认使用接口上限（%s）", limits))
+	cmd.Flags().Int("concurrency", cdn.DefaultBatchConcurrency, "同时提交的批次数量")
+	cmd.Flags().Bool("skip-quota-check", false, "跳过提交前的剩余配额检查")
+}
+
+// getBatchOptions 从命令标志中读取分批提交参数，每个批次单独使用会话配置的重试策略
+func getBatchOptions(cmd *cobra.Command, session *auth.Session) (cdn.BatchOptions, error) {
+	opts := cdn.BatchOptions{Concurrency: cdn.DefaultBatchConcurrency}
+	if flag := cmd.Flags().Lookup("batch-size"); flag != nil {
+		opts.BatchSize, _ = cmd.Flags().GetInt("batch-size")
+		opts.Concurrency, _ = cmd.Flags().GetInt("concurrency")
+		opts.SkipQuotaCheck, _ = cmd.Flags().GetBool("skip-quota-check")
+	}
+
+	if opts.BatchSize < 0 {
+		return opts, hwErrors.NewValidationError("--batch-size 不能小于 0")
+	}
+	if opts.Concurrency <= 0 {
+		return opts, hwErrors.NewValidationError("--concurrency 必须大于 0")
+	}
+
+	opts.Retryer = newRetryer(session)
+	return opts, nil
+}
+
+// printBatchResult 按输出格式打印分批提交结果
+func printBatchResult(formatter *output.Formatter, result *cdn.BatchResult, action string, wait bool) {
+	if !formatter.IsHumanReadable() {
+		if !wait {
+			formatter.Print(result)
+		}
+		return
+	}
+
+	if len(result.TaskIDs) > 0 {
+		formatter.PrintSuccess(fmt.Sprintf("CDN 缓存%s任务已提交成功", action))
+	}
+
+	if len(result.Batches) == 1 {
+		if result.TaskID != "" {
+			fmt.Printf("任务 ID: %s\n", result.TaskID)
+		}
+	} else {
+		fmt.Printf("共 %d 个 URL，分 %d 批提交:\n", result.URLCount, len(result.Batches))
+		rows := make([]batchRow, 0, len(result.Batches))
+		for i, batch := range result.Batches {
+			rows = append(rows, batchRow{
+				Batch:  i + 1,
+				TaskID: batch.TaskID,
+				Count:  len(batch.URLs),
+				Error:  batch.Error,
+			})
+		}
+		formatter.Print(rows)
+	}
+
+	if !wait && len(result.TaskIDs) > 0 {
+		fmt.Printf("可以使用以下命令查询任务状态:\n")
+		for _, taskID := range result.TaskIDs {
+			fmt.Printf("hwcctl cdn task %s\n", taskID)
+		}
+	}
+}
+
+// batchRow 表格模式下单个批次的展示行
+type batchRow struct {
+	Batch  int    `json:"batch" table:"批次"`
+	TaskID string `json:"task_id" table:"任务ID"`
+	Count  int    `json:"count" table:"URL数量"`
+	Error  string `json:"error,omitempty" table:"错误"`
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/output"
+	"github.com/ygqygq2/hwcctl/internal/utils"
+)
+
+// certExpiryWarningDays 上传证书剩余有效期少于该天数时给出警告
+const certExpiryWarningDays = 30
+
+// cdnCertCmd 代表 CDN HTTPS 证书命令组
+var cdnCertCmd = &cobra.Command{
+	Use:   "cert",
+	Short: "管理 CDN 加速域名的 HTTPS 证书",
+	Long:  `上传、更换和查询 CDN 加速域名的 HTTPS 证书。`,
+}
+
+// cdnCertSetCmd 代表设置证书命令
+var cdnCertSetCmd = &cobra.Command{
+	Use:   "set <domain>",
+	Short: "为 CDN 加速域名设置 HTTPS 证书",
+	Long: `为加速域名上传自有证书并开启 HTTPS。
+
+上传前会在本地校验证书：
+  - 证书链按 服务器证书、中间证书 的顺序排列
+  - 私钥与证书匹配
+  - 证书的 SAN 覆盖该域名
+  - 证书在有效期内
+
+线上的 HTTP/2、TLS 版本和 OCSP Stapling 配置保持不变。
+
+示例:
+  hwcctl cdn cert set cdn.example.com --cert fullchain.pem --key privkey.pem
+  hwcctl cdn cert set cdn.example.com --cert fullchain.pem --key privkey.pem --name example-2026`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runCDNCertSet,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// cdnCertListCmd 代表查询证书命令
+var cdnCertListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出 CDN 加速域名的 HTTPS 证书",
+	Long: `列出加速域名的证书名称和过期时间。
+
+使用 --expiring-within 时只列出在指定时长内过期（含已过期）的证书，
+存在这样的证书时以退出码 12 退出，适合用于证书过期监控。
+
+示例:
+  hwcctl cdn cert list
+  hwcctl cdn cert list --expiring-within 30d`,
+	Args:         cobra.NoArgs,
+	RunE:         runCDNCertList,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// certSetResult 设置证书的输出
+type certSetResult struct {
+	Domain      string               `json:"domain_name" yaml:"domain_name"`
+	CertName    string               `json:"cert_name" yaml:"cert_name"`
+	Certificate *cdn.CertificateInfo `json:"certificate" yaml:"certificate"`
+}
+
+// readCertificateFiles 读取证书和私钥文件
+func readCertificateFiles(certPath, keyPath string) ([]byte, []byte, error) {
+	if certPath == "" || keyPath == "" {
+		return nil, nil, hwErrors.NewValidationError("请使用 --cert 和 --key 指定证书和私钥文件")
+	}
+	certPEM, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, nil, hwErrors.NewValidationError(fmt.Sprintf("读取证书文件失败: %v", err))
+	}
+	keyPEM, err := os.ReadFile(keyPath)
+	if err != nil {
+		return nil, nil, hwErrors.NewValidationError(fmt.Sprintf("读取私钥文件失败: %v", err))
+	}
+	return certPEM, keyPEM, nil
+}
+
+func runCDNCertSet(cmd *cobra.Command, args []string) error {
+	domainName := args[0]
+	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+	formatter := output.NewFormatter(outputFormat)
+
+	certPath, _ := cmd.Flags().GetString("cert")
+	keyPath, _ := cmd.Flags().GetString("key")
+	certName, _ := cmd.Flags().GetString("name")
+
+	certPEM, keyPEM, err := readCertificateFiles(certPath, keyPath)
+	if err != nil {
+		return err
+	}
+
+	info, err := cdn.ValidateCertificate(certPEM, keyPEM, domainName, time.Now())
+	if err != nil {
+		return err
+	}
+	if info.DaysLeft < certExpiryWarningDays {
+		logx.Warnf("证书将在 %d 天后过期（%s）", info.DaysLeft, info.NotAfter)
+	}
+	if certName == "" {
+		certName = cdn.DefaultCertName(domainName, info)
+	}
+
+	logx.Infof("设置 CDN 加速域名证书: %s", domainName)
+	_, err = runWithCDNClient(cmd, func(client *cdn.Client) (interface{}, error) {
+		return nil, client.SetCertificate(domainName, certName, certPEM, keyPEM)
+	})
+	if err != nil {
+		return err
+	}
+
+	if formatter.IsHumanReadable() {
+		formatter.PrintSuccess(fmt.Sprintf("加速域名 %s 证书 %s 设置成功", domainName, certName))
+		return formatter.Print(info)
+	}
+	return formatter.Print(certSetResult{Domain: domainName, CertName: certName, Certificate: info})
+}
+
+func runCDNCertList(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+	formatter := output.NewFormatter(outputFormat)
+
+	opts := cdn.CertificateListOptions{Now: time.Now()}
+	opts.Domain, _ = cmd.Flags().GetString("domain")
+	if expiring, _ := cmd.Flags().GetString("expiring-within"); expiring != "" {
+		within, err := utils.ParseDuration(expiring)
+		if err != nil || within <= 0 {
+			return hwErrors.NewValidationError(fmt.Sprintf("无效的 --expiring-within: %s，示例: 30d、72h", expiring))
+		}
+		opts.ExpiringWithin = within
+	}
+
+	logx.Infof("查询 CDN 加速域名证书")
+	result, err := runWithCDNClient(cmd, func(client *cdn.Client) (interface{}, error) {
+		certs, err := client.ListCertificates(opts)
+		if err != nil {
+			return nil, hwErrors.Wrap(err, "查询加速域名证书失败")
+		}
+		return certs, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	certs := result.([]cdn.DomainCertificate)
+	if err := formatter.Print(certs); err != nil {
+		return err
+	}
+
+	if opts.ExpiringWithin > 0 && len(certs) > 0 {
+		return hwErrors.NewCertificateExpiringError(fmt.Sprintf("%d 个加速域名的证书将在 %s 内过期",
+			len(certs), cmd.Flag("expiring-within").Value.String()))
+	}
+	return nil
+}
+
+func init() {
+	cdnCmd.AddCommand(cdnCertCmd)
+	cdnCertCmd.AddCommand(cdnCertSetCmd, cdnCertListCmd)
+
+	cdnCertSetCmd.Flags().String("cert", "", "PEM 格式的证书文件，包含服务器证书和中间证书")
+	cdnCertSetCmd.Flags().String("key", "", "PEM 格式的私钥文件")
+	cdnCertSetCmd.Flags().String("name", "", "证书名称（3-64 个字符），默认为 域名-过期日期")
+
+	cdnCertListCmd.Flags().String("domain", "", "按域名模糊过滤")
+	cdnCertListCmd.Flags().String("expiring-within", "", "只列出在指定时长内过期的证书（如 30d），存在时以退出码 12 退出")
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCDNCertCommands(t *testing.T) {
+	for _, name := range []string{"set", "list"} {
+		found := false
+		for _, sub := range cdnCertCmd.Commands() {
+			if sub.Name() == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("cert命令应该有%s子命令", name)
+		}
+	}
+
+	for _, flag := range []string{"cert", "key", "name"} {
+		if cdnCertSetCmd.Flags().Lookup(flag) == nil {
+			t.Errorf("cert set命令应该有--%s标志", flag)
+		}
+	}
+	if cdnCertListCmd.Flags().Lookup("expiring-within") == nil {
+		t.Error("cert list命令应该有--expiring-within标志")
+	}
+}
+
+func TestReadCertificateFiles(t *testing.T) {
+	dir := t.TempDir()
+	certPath := filepath.Join(dir, "cert.pem")
+	keyPath := filepath.Join(dir, "key.pem")
+	os.WriteFile(certPath, []byte("CERT"), 0600)
+	os.WriteFile(keyPath, []byte("KEY"), 0600)
+
+	certPEM, keyPEM, err := readCertificateFiles(certPath, keyPath)
+	if err != nil || string(certPEM) != "CERT" || string(keyPEM) != "KEY" {
+		t.Errorf("读取证书文件错误: %q %q %v", certPEM, keyPEM, err)
+	}
+
+	if _, _, err := readCertificateFiles(certPath, ""); err == nil {
+		t.Error("未指定私钥时应返回错误")
+	}
+	if _, _, err := readCertificateFiles(filepath.Join(dir, "missing.pem"), keyPath); err == nil {
+		t.Error("证书文件不存在时应返回错误")
+	}
+}
+
+func TestRunCDNCertListInvalidExpiringWithin(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("domain", "", "")
+	cmd.Flags().String("expiring-within", "", "")
+	cmd.Flags().Set("expiring-within", "soon")
+
+	if err := runCDNCertList(cmd, nil); err == nil {
+		t.Error("无效的 --expiring-within 应返回错误")
+	}
+}
@@ -0,0 +1,335 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/output"
+	"github.com/ygqygq2/hwcctl/internal/utils"
+)
+
+// cdnDomainCmd 代表 CDN 加速域名命令组
+var cdnDomainCmd = &cobra.Command{
+	Use:   "domain",
+	Short: "管理 CDN 加速域名",
+	Long:  `管理 CDN 加速域名的生命周期，包括查询、创建、删除、启用和停用。`,
+}
+
+// cdnDomainListCmd 代表列出加速域名命令
+var cdnDomainListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出 CDN 加速域名",
+	Long: `列出当前账号下的 CDN 加速域名，支持按域名、业务类型、服务范围和状态过滤。
+
+示例:
+  hwcctl cdn domain list
+  hwcctl cdn domain list --name example.com --status online --all`,
+	Args:         cobra.NoArgs,
+	RunE:         runCDNDomainList,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// cdnDomainGetCmd 代表查询加速域名详情命令
+var cdnDomainGetCmd = &cobra.Command{
+	Use:          "get <domain>",
+	Short:        "查询 CDN 加速域名详情",
+	Long:         `查询指定加速域名的基本信息和源站配置。`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runCDNDomainGet,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// cdnDomainCreateCmd 代表创建加速域名命令
+var cdnDomainCreateCmd = &cobra.Command{
+	Use:   "create <domain>",
+	Short: "创建 CDN 加速域名",
+	Long: `创建 CDN 加速域名。
+
+--origin 格式为 类型:地址，类型支持 ipaddr、domain、obs_bucket，可以指定多次，
+第一个源站为主源站，其余为备源站。
+
+示例:
+  hwcctl cdn domain create cdn.example.com --origin domain:origin.example.com
+  hwcctl cdn domain create cdn.example.com --business-type download --service-area global \
+    --origin ipaddr:1.2.3.4 --origin ipaddr:5.6.7.8`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runCDNDomainCreate,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// cdnDomainDeleteCmd 代表删除加速域名命令
+var cdnDomainDeleteCmd = &cobra.Command{
+	Use:   "delete <domain>",
+	Short: "删除 CDN 加速域名",
+	Long: `删除 CDN 加速域名，域名需先停用。
+
+删除前会要求确认，使用 --yes 跳过确认。`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runCDNDomainDelete,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// cdnDomainEnableCmd 代表启用加速域名命令
+var cdnDomainEnableCmd = &cobra.Command{
+	Use:          "enable <domain>",
+	Short:        "启用 CDN 加速域名",
+	Args:         cobra.ExactArgs(1),
+	RunE:         runCDNDomainEnable,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// cdnDomainDisableCmd 代表停用加速域名命令
+var cdnDomainDisableCmd = &cobra.Command{
+	Use:          "disable <domain>",
+	Short:        "停用 CDN 加速域名",
+	Args:         cobra.ExactArgs(1),
+	RunE:         runCDNDomainDisable,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// getDomainListOptions 从命令标志中读取加速域名查询条件
+func getDomainListOptions(cmd *cobra.Command) (cdn.DomainListOptions, error) {
+	opts := cdn.DomainListOptions{}
+	opts.Name, _ = cmd.Flags().GetString("name")
+	opts.BusinessType, _ = cmd.Flags().GetString("business-type")
+	opts.ServiceArea, _ = cmd.Flags().GetString("service-area")
+	opts.Status, _ = cmd.Flags().GetString("status")
+	opts.PageSize, _ = cmd.Flags().GetInt("page-size")
+	opts.PageNumber, _ = cmd.Flags().GetInt("page")
+	opts.All, _ = cmd.Flags().GetBool("all")
+
+	if opts.BusinessType != "" && !utils.StringSliceContains(cdn.BusinessTypes, opts.BusinessType) {
+		return opts, hwErrors.NewValidationError(fmt.Sprintf("不支持的业务类型: %s，支持的类型: %s",
+			opts.BusinessType, strings.Join(cdn.BusinessTypes, ", ")))
+	}
+	if opts.ServiceArea != "" && !utils.StringSliceContains(cdn.ServiceAreas, opts.ServiceArea) {
+		return opts, hwErrors.NewValidationError(fmt.Sprintf("不支持的服务范围: %s，支持的范围: %s",
+			opts.ServiceArea, strings.Join(cdn.ServiceAreas, ", ")))
+	}
+	if opts.Status != "" && !utils.StringSliceContains(cdn.DomainStatuses, opts.Status) {
+		return opts, hwErrors.NewValidationError(fmt.Sprintf("不支持的域名状态: %s，支持的状态: %s",
+			opts.Status, strings.Join(cdn.DomainStatuses, ", ")))
+	}
+	if opts.PageSize <= 0 || opts.PageSize > cdn.MaxDomainListPageSize {
+		return opts, hwErrors.NewValidationError(fmt.Sprintf("--page-size 取值范围为 1-%d", cdn.MaxDomainListPageSize))
+	}
+	if opts.PageNumber <= 0 {
+		return opts, hwErrors.NewValidationError("--page 必须大于 0")
+	}
+
+	return opts, nil
+}
+
+// parseOrigins 解析 --origin 参数，第一个源站为主源站
+func parseOrigins(values []string) ([]cdn.DomainOrigin, error) {
+	origins := make([]cdn.DomainOrigin, 0, len(values))
+	for i, value := range values {
+		originType, address, ok := strings.Cut(value, ":")
+		if !ok || address == "" {
+			return nil, hwErrors.NewValidationError(fmt.Sprintf("源站格式无效: %s，格式应为 类型:地址", value))
+		}
+		if !utils.StringSliceContains(cdn.OriginTypes, originType) {
+			return nil, hwErrors.NewValidationError(fmt.Sprintf("不支持的源站类型: %s，支持的类型: %s",
+				originType, strings.Join(cdn.OriginTypes, ", ")))
+		}
+		origins = append(origins, cdn.DomainOrigin{
+			Type:    originType,
+			Address: address,
+			Primary: i == 0,
+		})
+	}
+	return origins, nil
+}
+
+// printDomainDetail 以表格形式打印加速域名详情
+func printDomainDetail(formatter *output.Formatter, detail *cdn.DomainDetail) {
+	formatter.Print(detail.Domain)
+	if len(detail.Origins) > 0 {
+		fmt.Println("\n源站:")
+		formatter.Print(detail.Origins)
+	}
+}
+
+func runCDNDomainList(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+	formatter := output.NewFormatter(outputFormat)
+
+	opts, err := getDomainListOptions(cmd)
+	if err != nil {
+		return err
+	}
+
+	logx.Infof("查询 CDN 加速域名")
+	result, err := runWithCDNClient(cmd, func(client *cdn.Client) (interface{}, error) {
+		domainList, err := client.ListDomains(opts)
+		if err != nil {
+			return nil, hwErrors.Wrap(err, "查询加速域名失败")
+		}
+		return domainList, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	domainList := result.(*cdn.DomainList)
+	if err := formatter.Print(domainList.Domains); err != nil {
+		return err
+	}
+
+	if formatter.IsHumanReadable() && outputFormat == "table" && len(domainList.Domains) > 0 {
+		if opts.All {
+			fmt.Printf("\n共 %d 个域名\n", domainList.Total)
+		} else {
+			fmt.Printf("\n第 %d 页，本页 %d 个，共 %d 个域名\n", opts.PageNumber, len(domainList.Domains), domainList.Total)
+		}
+	}
+
+	return nil
+}
+
+func runCDNDomainGet(cmd *cobra.Command, args []string) error {
+	domainName := args[0]
+	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+	formatter := output.NewFormatter(outputFormat)
+
+	logx.Infof("查询 CDN 加速域名详情: %s", domainName)
+	result, err := runWithCDNClient(cmd, func(client *cdn.Client) (interface{}, error) {
+		detail, err := client.GetDomain(domainName)
+		if err != nil {
+			return nil, hwErrors.Wrap(err, "查询加速域名详情失败")
+		}
+		return detail, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	detail := result.(*cdn.DomainDetail)
+	if formatter.IsHumanReadable() {
+		printDomainDetail(formatter, detail)
+	} else {
+		formatter.Print(detail)
+	}
+	return nil
+}
+
+func runCDNDomainCreate(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+	formatter := output.NewFormatter(outputFormat)
+
+	originValues, _ := cmd.Flags().GetStringSlice("origin")
+	if len(originValues) == 0 {
+		return hwErrors.NewValidationError("请使用 --origin 指定至少一个源站")
+	}
+	origins, err := parseOrigins(originValues)
+	if err != nil {
+		return err
+	}
+
+	opts := cdn.DomainCreateOptions{Name: args[0], Origins: origins}
+	opts.BusinessType, _ = cmd.Flags().GetString("business-type")
+	opts.ServiceArea, _ = cmd.Flags().GetString("service-area")
+
+	logx.Infof("创建 CDN 加速域名: %s", opts.Name)
+	session, err := getSession(cmd)
+	if err != nil {
+		return hwErrors.NewValidationError(fmt.Sprintf("加载配置失败: %v", err))
+	}
+	client, err := cdn.NewClient(session)
+	if err != nil {
+		return err
+	}
+
+	// 创建操作不重试，避免重复提交
+	detail, err := client.CreateDomain(opts)
+	if err != nil {
+		return err
+	}
+
+	if formatter.IsHumanReadable() {
+		formatter.PrintSuccess(fmt.Sprintf("加速域名 %s 创建成功", detail.Name))
+		printDomainDetail(formatter, detail)
+		if detail.CNAME != "" {
+			fmt.Printf("\n请将域名 %s 的 CNAME 记录指向 %s\n", detail.Name, detail.CNAME)
+		}
+	} else {
+		formatter.Print(detail)
+	}
+	return nil
+}
+
+// confirmAction 请求用户确认，输入 y 或 yes 时返回 true
+func confirmAction(cmd *cobra.Command, prompt string) bool {
+	fmt.Fprintf(cmd.ErrOrStderr(), "%s [y/N]: ", prompt)
+	reader := bufio.NewReader(cmd.InOrStdin())
+	answer, _ := reader.ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func runCDNDomainDelete(cmd *cobra.Command, args []string) error {
+	domainName := args[0]
+	yes, _ := cmd.Flags().GetBool("yes")
+	if !yes && !confirmAction(cmd, fmt.Sprintf("确认删除加速域名 %s？", domainName)) {
+		return hwErrors.NewValidationError("已取消删除")
+	}
+
+	return changeCDNDomain(cmd, domainName, "删除", (*cdn.Client).DeleteDomain)
+}
+
+func runCDNDomainEnable(cmd *cobra.Command, args []string) error {
+	return changeCDNDomain(cmd, args[0], "启用", (*cdn.Client).EnableDomain)
+}
+
+func runCDNDomainDisable(cmd *cobra.Command, args []string) error {
+	return changeCDNDomain(cmd, args[0], "停用", (*cdn.Client).DisableDomain)
+}
+
+// changeCDNDomain 执行删除/启用/停用操作并输出结果
+func changeCDNDomain(cmd *cobra.Command, domainName, action string,
+	change func(client *cdn.Client, name string) (*cdn.Domain, error)) error {
+	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+	formatter := output.NewFormatter(outputFormat)
+
+	logx.Infof("%s CDN 加速域名: %s", action, domainName)
+	result, err := runWithCDNClient(cmd, func(client *cdn.Client) (interface{}, error) {
+		domain, err := change(client, domainName)
+		if err != nil {
+			return nil, hwErrors.Wrap(err, fmt.Sprintf("%s加速域名失败", action))
+		}
+		return domain, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if formatter.IsHumanReadable() {
+		formatter.PrintSuccess(fmt.Sprintf("加速域名 %s %s成功", domainName, action))
+	}
+	return formatter.Print(result)
+}
+
+func init() {
+	cdnCmd.AddCommand(cdnDomainCmd)
+	cdnDomainCmd.AddCommand(cdnDomainListCmd, cdnDomainGetCmd, cdnDomainCreateCmd,
+		cdnDomainDeleteCmd, cdnDomainEnableCmd, cdnDomainDisableCmd)
+
+	cdnDomainListCmd.Flags().String("name", "", "按域名模糊过滤")
+	cdnDomainListCmd.Flags().String("business-type", "", "业务类型过滤："+strings.Join(cdn.BusinessTypes, "|"))
+	cdnDomainListCmd.Flags().String("service-area", "", "服务范围过滤："+strings.Join(cdn.ServiceAreas, "|"))
+	cdnDomainListCmd.Flags().String("status", "", "域名状态过滤："+strings.Join(cdn.DomainStatuses, "|"))
+	cdnDomainListCmd.Flags().Int("page-size", cdn.DefaultDomainListPageSize, "每页域名数量")
+	cdnDomainListCmd.Flags().Int("page", 1, "页码")
+	cdnDomainListCmd.Flags().Bool("all", false, "自动翻页获取全部域名")
+
+	cdnDomainCreateCmd.Flags().String("business-type", "web", "业务类型："+strings.Join(cdn.BusinessTypes, "|"))
+	cdnDomainCreateCmd.Flags().String("service-area", "mainland_china", "服务范围："+strings.Join(cdn.ServiceAreas, "|"))
+	cdnDomainCreateCmd.Flags().StringSlice("origin", []string{}, "源站，格式为 类型:地址，可指定多次，第一个为主源站")
+
+	cdnDomainDeleteCmd.Flags().BoolP("yes", "y", false, "跳过删除确认")
+}
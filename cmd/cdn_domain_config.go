@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/output"
+	"gopkg.in/yaml.v3"
+)
+
+// cdnDomainConfigCmd 代表加速域名配置命令组
+var cdnDomainConfigCmd = &cobra.Command{
+	Use:   "config",
+	Short: "查询和更新 CDN 加速域名配置",
+	Long:  `查询加速域名的完整配置，或根据配置文件只更新发生变化的配置项。`,
+}
+
+// cdnDomainConfigGetCmd 代表查询加速域名配置命令
+var cdnDomainConfigGetCmd = &cobra.Command{
+	Use:   "get <domain>",
+	Short: "查询 CDN 加速域名的完整配置",
+	Long: `查询加速域名的完整配置，以 YAML 或 JSON 格式输出。
+
+表格格式下输出 YAML，输出内容可直接作为 apply 的配置文件。
+
+示例:
+  hwcctl cdn domain config get cdn.example.com > cdn.example.com.yaml
+  hwcctl cdn domain config get cdn.example.com --output json`,
+	Args:         cobra.ExactArgs(1),
+	RunE:         runCDNDomainConfigGet,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// cdnDomainConfigApplyCmd 代表更新加速域名配置命令
+var cdnDomainConfigApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "根据配置文件更新 CDN 加速域名配置",
+	Long: `将配置文件与线上配置比较，只更新发生变化的配置项。
+
+配置文件格式与 config get 的输出一致，configs 中只需包含需要管理的配置项，
+未出现的配置项保持不变。支持更新的配置项:
+  ` + strings.Join(cdn.ConfigSections, "\n  ") + `
+
+其他配置项（如 https 证书）会被忽略，证书请使用 cdn cert 命令管理。
+
+示例:
+  hwcctl cdn domain config apply -f cdn.example.com.yaml --dry-run
+  hwcctl cdn domain config apply -f cdn.example.com.yaml`,
+	Args:         cobra.NoArgs,
+	RunE:         runCDNDomainConfigApply,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// readConfigFile 读取配置文件内容，path 为 - 时从标准输入读取
+func readConfigFile(cmd *cobra.Command, path string) ([]byte, error) {
+	var reader io.Reader
+	if path == "-" {
+		reader = cmd.InOrStdin()
+	} else {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, hwErrors.NewValidationError(fmt.Sprintf("打开配置文件失败: %v", err))
+		}
+		defer file.Close()
+		reader = file
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("读取配置文件失败: %v", err))
+	}
+	return data, nil
+}
+
+// readDomainConfigFile 读取单个域名的期望配置文件
+func readDomainConfigFile(cmd *cobra.Command, path string) (*cdn.DomainConfig, error) {
+	data, err := readConfigFile(cmd, path)
+	if err != nil {
+		return nil, err
+	}
+	return cdn.ParseDomainConfig(data)
+}
+
+// printConfigDiff 以 -/+ 形式打印配置差异
+func printConfigDiff(w io.Writer, diff *cdn.ConfigDiff) {
+	if !diff.HasChanges() {
+		fmt.Fprintf(w, "加速域名 %s 的配置与线上一致，无需更新\n", diff.Domain)
+		return
+	}
+
+	fmt.Fprintf(w, "加速域名 %s 有 %d 个配置项需要更新:\n", diff.Domain, len(diff.Changes))
+	for _, change := range diff.Changes {
+		fmt.Fprintf(w, "\n~ %s (%s)\n", change.Section, change.Action)
+		if change.Current != nil {
+			writePrefixedYAML(w, "- ", change.Current)
+		}
+		writePrefixedYAML(w, "+ ", change.Desired)
+	}
+}
+
+// writePrefixedYAML 将数据编码为 YAML 并为每行添加前缀
+func writePrefixedYAML(w io.Writer, prefix string, v interface{}) {
+	data, err := yaml.Marshal(v)
+	if err != nil {
+		fmt.Fprintf(w, "%s%v\n", prefix, v)
+		return
+	}
+	for _, line := range strings.Split(strings.TrimRight(string(data), "\n"), "\n") {
+		fmt.Fprintf(w, "%s  %s\n", prefix, line)
+	}
+}
+
+func runCDNDomainConfigGet(cmd *cobra.Command, args []string) error {
+	domainName := args[0]
+	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+	formatter := output.NewFormatter(outputFormat)
+
+	logx.Infof("查询 CDN 加速域名配置: %s", domainName)
+	result, err := runWithCDNClient(cmd, func(client *cdn.Client) (interface{}, error) {
+		config, err := client.GetDomainConfig(domainName)
+		if err != nil {
+			return nil, hwErrors.Wrap(err, "查询加速域名配置失败")
+		}
+		return config, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// 配置为嵌套结构，表格格式下以 YAML 输出
+	if formatter.IsHumanReadable() {
+		formatter = output.NewFormatter(string(output.FormatYAML))
+	}
+	return formatter.Print(result)
+}
+
+func runCDNDomainConfigApply(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+	formatter := output.NewFormatter(outputFormat)
+
+	file, _ := cmd.Flags().GetString("file")
+	if file == "" {
+		return hwErrors.NewValidationError("请使用 --file 指定配置文件")
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	desired, err := readDomainConfigFile(cmd, file)
+	if err != nil {
+		return err
+	}
+
+	logx.Infof("比较加速域名 %s 的配置", desired.Domain)
+	result, err := runWithCDNClient(cmd, func(client *cdn.Client) (interface{}, error) {
+		live, err := client.GetDomainConfig(desired.Domain)
+		if err != nil {
+			return nil, hwErrors.Wrap(err, "查询加速域名配置失败")
+		}
+		return cdn.DiffDomainConfig(live, desired)
+	})
+	if err != nil {
+		return err
+	}
+
+	diff := result.(*cdn.ConfigDiff)
+	for _, section := range diff.Ignored {
+		logx.Warnf("配置项 %s 不支持通过 apply 更新，已忽略", section)
+	}
+
+	tableOutput := formatter.IsHumanReadable()
+	if tableOutput {
+		printConfigDiff(os.Stdout, diff)
+	}
+
+	if dryRun || !diff.HasChanges() {
+		if !tableOutput {
+			return formatter.Print(diff)
+		}
+		return nil
+	}
+
+	// 提交的配置项由线上配置与期望配置合并而成，其中期望配置的字段优先
+	// 重试时再次提交的是同一份完整配置，已生效的更新不会因重复提交而改变结果，可以安全重试
+	_, err = runWithCDNClient(cmd, func(client *cdn.Client) (interface{}, error) {
+		return nil, client.ApplyDomainConfig(diff)
+	})
+	if err != nil {
+		return err
+	}
+
+	if tableOutput {
+		fmt.Println()
+		formatter.PrintSuccess(fmt.Sprintf("加速域名 %s 配置更新成功", diff.Domain))
+		return nil
+	}
+	return formatter.Print(diff)
+}
+
+func init() {
+	cdnDomainCmd.AddCommand(cdnDomainConfigCmd)
+	cdnDomainConfigCmd.AddCommand(cdnDomainConfigGetCmd, cdnDomainConfigApplyCmd)
+
+	cdnDomainConfigApplyCmd.Flags().StringP("file", "f", "", "配置文件路径（YAML 或 JSON），- 表示从标准输入读取")
+	cdnDomainConfigApplyCmd.Flags().Bool("dry-run", false, "只显示差异，不更新配置")
+}
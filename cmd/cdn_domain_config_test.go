@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+)
+
+func TestCDNDomainConfigCommands(t *testing.T) {
+	for _, name := range []string{"get", "apply"} {
+		found := false
+		for _, sub := range cdnDomainConfigCmd.Commands() {
+			if sub.Name() == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("domain config命令应该有%s子命令", name)
+		}
+	}
+
+	if cdnDomainConfigApplyCmd.Flags().ShorthandLookup("f") == nil {
+		t.Error("apply命令应该有-f标志")
+	}
+}
+
+func TestReadDomainConfigFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "domain.yaml")
+	content := "domain: cdn.example.com\nconfigs:\n  compress:\n    status: \"on\"\n"
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+
+	config, err := readDomainConfigFile(&cobra.Command{}, path)
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if config.Domain != "cdn.example.com" {
+		t.Errorf("域名错误: %s", config.Domain)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.SetIn(strings.NewReader(content))
+	if _, err := readDomainConfigFile(cmd, "-"); err != nil {
+		t.Errorf("从标准输入读取失败: %v", err)
+	}
+
+	if _, err := readDomainConfigFile(&cobra.Command{}, filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("期望文件不存在时返回错误")
+	}
+}
+
+func TestPrintConfigDiff(t *testing.T) {
+	var buf bytes.Buffer
+	printConfigDiff(&buf, &cdn.ConfigDiff{Domain: "cdn.example.com"})
+	if !strings.Contains(buf.String(), "无需更新") {
+		t.Errorf("无变更时输出错误: %s", buf.String())
+	}
+
+	buf.Reset()
+	printConfigDiff(&buf, &cdn.ConfigDiff{
+		Domain: "cdn.example.com",
+		Changes: []cdn.ConfigChange{{
+			Section: "compress",
+			Action:  cdn.ConfigActionUpdate,
+			Current: map[string]interface{}{"status": "off"},
+			Desired: map[string]interface{}{"status": "on"},
+		}},
+	})
+	out := buf.String()
+	for _, want := range []string{"~ compress (update)", "-   status: \"off\"", "+   status: \"on\""} {
+		if !strings.Contains(out, want) {
+			t.Errorf("输出中缺少 %q:\n%s", want, out)
+		}
+	}
+}
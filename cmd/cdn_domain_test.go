@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestCDNDomainCommands(t *testing.T) {
+	expected := []string{"list", "get", "create", "delete", "enable", "disable"}
+	for _, name := range expected {
+		found := false
+		for _, sub := range cdnDomainCmd.Commands() {
+			if sub.Name() == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("domain命令应该有%s子命令", name)
+		}
+	}
+}
+
+func TestParseOrigins(t *testing.T) {
+	origins, err := parseOrigins([]string{"domain:origin.example.com", "ipaddr:1.2.3.4"})
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if len(origins) != 2 || !origins[0].Primary || origins[1].Primary {
+		t.Errorf("第一个源站应为主源站: %+v", origins)
+	}
+	if origins[0].Address != "origin.example.com" || origins[1].Type != "ipaddr" {
+		t.Errorf("源站解析错误: %+v", origins)
+	}
+
+	for _, value := range []string{"origin.example.com", "ftp:1.2.3.4", "ipaddr:"} {
+		if _, err := parseOrigins([]string{value}); err == nil {
+			t.Errorf("期望 %s 解析失败", value)
+		}
+	}
+}
+
+// newDomainListTestCmd 创建带有域名查询标志的测试命令
+func newDomainListTestCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("name", "", "")
+	cmd.Flags().String("business-type", "", "")
+	cmd.Flags().String("service-area", "", "")
+	cmd.Flags().String("status", "", "")
+	cmd.Flags().Int("page-size", 30, "")
+	cmd.Flags().Int("page", 1, "")
+	cmd.Flags().Bool("all", false, "")
+	return cmd
+}
+
+func TestGetDomainListOptionsValidation(t *testing.T) {
+	cmd := newDomainListTestCmd()
+	cmd.Flags().Set("status", "online")
+	opts, err := getDomainListOptions(cmd)
+	if err != nil || opts.Status != "online" {
+		t.Errorf("期望参数有效，实际为 %+v, %v", opts, err)
+	}
+
+	invalid := map[string]string{
+		"business-type": "game",
+		"service-area":  "mars",
+		"status":        "unknown",
+		"page-size":     "0",
+		"page":          "0",
+	}
+	for flag, value := range invalid {
+		cmd := newDomainListTestCmd()
+		cmd.Flags().Set(flag, value)
+		if _, err := getDomainListOptions(cmd); err == nil {
+			t.Errorf("期望 --%s=%s 返回错误", flag, value)
+		}
+	}
+}
+
+func TestRunCDNDomainDeleteCancelled(t *testing.T) {
+	cmd := &cobra.Command{}
+	cmd.Flags().BoolP("yes", "y", false, "")
+	cmd.SetIn(strings.NewReader("n\n"))
+	cmd.SetErr(&strings.Builder{})
+
+	err := runCDNDomainDelete(cmd, []string{"cdn.example.com"})
+	if err == nil || !strings.Contains(err.Error(), "已取消") {
+		t.Errorf("期望取消删除，实际为 %v", err)
+	}
+}
+
+func TestConfirmAction(t *testing.T) {
+	for input, expected := range map[string]bool{"y\n": true, "YES\n": true, "n\n": false, "\n": false, "": false} {
+		cmd := &cobra.Command{}
+		cmd.SetIn(strings.NewReader(input))
+		cmd.SetErr(&strings.Builder{})
+		if got := confirmAction(cmd, "确认？"); got != expected {
+			t.Errorf("输入 %q 期望 %v，实际为 %v", input, expected, got)
+		}
+	}
+}
@@ -0,0 +1,226 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/output"
+	"github.com/ygqygq2/hwcctl/internal/utils"
+)
+
+// logDateLayout --date 参数的日期格式
+const logDateLayout = "2006-01-02"
+
+// cdnLogsCmd 代表 CDN 访问日志命令组
+var cdnLogsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "查询和下载 CDN 访问日志",
+	Long:  `查询和下载 CDN 加速域名的访问日志，日志按小时切分并以 gzip 格式压缩。`,
+}
+
+// cdnLogsListCmd 代表查询日志文件命令
+var cdnLogsListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出 CDN 访问日志文件",
+	Long: `列出加速域名在指定日期的访问日志文件，默认为当天。
+
+示例:
+  hwcctl cdn logs list --domain www.example.com
+  hwcctl cdn logs list --domain www.example.com --date 2026-10-15 --output json`,
+	Args:         cobra.NoArgs,
+	RunE:         runCDNLogsList,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// cdnLogsDownloadCmd 代表下载日志文件命令
+var cdnLogsDownloadCmd = &cobra.Command{
+	Use:   "download",
+	Short: "下载 CDN 访问日志",
+	Long: `并发下载加速域名在指定日期的访问日志到本地目录。
+
+已完整下载的文件会被跳过；中断的下载保存为 .part 文件，再次执行时从断点继续，
+下载完成后校验文件大小。
+
+使用 --stdout 时不保存文件，按时间顺序下载并将解压后的日志逐行输出到标准输出，
+便于通过管道交给其他工具分析。
+
+示例:
+  hwcctl cdn logs download --domain www.example.com --date 2026-10-15 --dir ./logs
+  hwcctl cdn logs download --domain www.example.com --concurrency 8
+  hwcctl cdn logs download --domain www.example.com --date 2026-10-15 --stdout | grep " 404 "`,
+	Args:         cobra.NoArgs,
+	RunE:         runCDNLogsDownload,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// logFileRow 日志文件的表格输出
+type logFileRow struct {
+	Name      string `table:"文件名"`
+	StartTime string `table:"开始时间"`
+	EndTime   string `table:"结束时间"`
+	Size      string `table:"大小"`
+}
+
+// logDownloadRow 日志下载结果的表格输出
+type logDownloadRow struct {
+	Name   string `table:"文件名"`
+	Size   string `table:"大小"`
+	Status string `table:"状态"`
+	Error  string `table:"错误"`
+}
+
+// getLogsQuery 从命令标志中读取域名和日期，返回该日期的起止时间
+func getLogsQuery(cmd *cobra.Command, now time.Time) (string, time.Time, time.Time, error) {
+	domain, _ := cmd.Flags().GetString("domain")
+	if domain == "" {
+		return "", time.Time{}, time.Time{}, hwErrors.NewValidationError("请使用 --domain 指定加速域名")
+	}
+
+	date, _ := cmd.Flags().GetString("date")
+	var start time.Time
+	if date == "" {
+		start = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	} else {
+		var err error
+		start, err = time.ParseInLocation(logDateLayout, date, time.Local)
+		if err != nil {
+			return "", time.Time{}, time.Time{}, hwErrors.NewValidationError(fmt.Sprintf("无效的日期: %s，格式为 YYYY-MM-DD", date))
+		}
+	}
+	return domain, start, start.AddDate(0, 0, 1), nil
+}
+
+// listLogFiles 查询日志文件列表
+func listLogFiles(cmd *cobra.Command, domain string, start, end time.Time) ([]cdn.LogFile, error) {
+	logx.Infof("查询域名 %s 的访问日志: %s", domain, start.Format(logDateLayout))
+	result, err := runWithCDNClient(cmd, func(client *cdn.Client) (interface{}, error) {
+		return client.ListLogs(domain, start, end)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.([]cdn.LogFile), nil
+}
+
+func runCDNLogsList(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+	formatter := output.NewFormatter(outputFormat)
+
+	domain, start, end, err := getLogsQuery(cmd, time.Now())
+	if err != nil {
+		return err
+	}
+
+	logs, err := listLogFiles(cmd, domain, start, end)
+	if err != nil {
+		return err
+	}
+
+	if !formatter.IsHumanReadable() {
+		return formatter.Print(logs)
+	}
+
+	rows := make([]logFileRow, 0, len(logs))
+	var total int64
+	for _, log := range logs {
+		rows = append(rows, logFileRow{
+			Name:      log.Name,
+			StartTime: log.StartTime,
+			EndTime:   log.EndTime,
+			Size:      utils.FormatBytes(float64(log.Size)),
+		})
+		total += log.Size
+	}
+	if err := formatter.Print(rows); err != nil {
+		return err
+	}
+	fmt.Printf("\n共 %d 个文件，%s\n", len(logs), utils.FormatBytes(float64(total)))
+	return nil
+}
+
+func runCDNLogsDownload(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+	formatter := output.NewFormatter(outputFormat)
+
+	domain, start, end, err := getLogsQuery(cmd, time.Now())
+	if err != nil {
+		return err
+	}
+	opts := cdn.LogDownloadOptions{}
+	opts.Dir, _ = cmd.Flags().GetString("dir")
+	opts.Concurrency, _ = cmd.Flags().GetInt("concurrency")
+	if opts.Concurrency <= 0 {
+		return hwErrors.NewValidationError("--concurrency 必须大于 0")
+	}
+	toStdout, _ := cmd.Flags().GetBool("stdout")
+
+	session, err := getSession(cmd)
+	if err != nil {
+		return hwErrors.NewValidationError(fmt.Sprintf("加载配置失败: %v", err))
+	}
+	opts.Retryer = newRetryer(session)
+
+	logs, err := listLogFiles(cmd, domain, start, end)
+	if err != nil {
+		return err
+	}
+	if len(logs) == 0 {
+		logx.Warnf("域名 %s 在 %s 没有访问日志", domain, start.Format(logDateLayout))
+		return nil
+	}
+
+	if toStdout {
+		return cdn.StreamLogs(context.Background(), logs, cmd.OutOrStdout(), opts)
+	}
+
+	if formatter.IsHumanReadable() {
+		opts.Progress = os.Stderr
+	}
+	logx.Infof("下载 %d 个日志文件到 %s", len(logs), opts.Dir)
+	results, downloadErr := cdn.DownloadLogs(context.Background(), logs, opts)
+	if results == nil {
+		return downloadErr
+	}
+
+	if formatter.IsHumanReadable() {
+		rows := make([]logDownloadRow, 0, len(results))
+		for _, result := range results {
+			rows = append(rows, logDownloadRow{
+				Name:   result.Name,
+				Size:   utils.FormatBytes(float64(result.Size)),
+				Status: result.Status,
+				Error:  result.Error,
+			})
+		}
+		if err := formatter.Print(rows); err != nil {
+			return err
+		}
+	} else if err := formatter.Print(results); err != nil {
+		return err
+	}
+
+	if downloadErr != nil {
+		return downloadErr
+	}
+	return nil
+}
+
+func init() {
+	cdnCmd.AddCommand(cdnLogsCmd)
+	cdnLogsCmd.AddCommand(cdnLogsListCmd, cdnLogsDownloadCmd)
+
+	for _, c := range []*cobra.Command{cdnLogsListCmd, cdnLogsDownloadCmd} {
+		c.Flags().String("domain", "", "加速域名")
+		c.Flags().String("date", "", "日志日期（YYYY-MM-DD），默认为当天")
+	}
+
+	cdnLogsDownloadCmd.Flags().String("dir", ".", "日志保存目录")
+	cdnLogsDownloadCmd.Flags().Int("concurrency", cdn.DefaultBatchConcurrency, "同时下载的文件数量")
+	cdnLogsDownloadCmd.Flags().Bool("stdout", false, "不保存文件，将解压后的日志输出到标准输出")
+}
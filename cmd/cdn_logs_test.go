@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/auth"
+)
+
+func TestCDNLogsCommands(t *testing.T) {
+	found := map[string]bool{}
+	for _, sub := range cdnLogsCmd.Commands() {
+		found[sub.Name()] = true
+	}
+	for _, name := range []string{"list", "download"} {
+		if !found[name] {
+			t.Errorf("logs命令应该有%s子命令", name)
+		}
+	}
+
+	for _, flag := range []string{"dir", "concurrency", "stdout", "date"} {
+		if cdnLogsDownloadCmd.Flags().Lookup(flag) == nil {
+			t.Errorf("download命令应该有--%s标志", flag)
+		}
+	}
+}
+
+// newLogsTestCmd 创建带有 logs 标志的测试命令
+func newLogsTestCmd(domain, date string) *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.PersistentFlags().String("output", "table", "")
+	cmd.Flags().String("domain", domain, "")
+	cmd.Flags().String("date", date, "")
+	cmd.Flags().String("dir", ".", "")
+	cmd.Flags().Int("concurrency", 2, "")
+	cmd.Flags().Bool("stdout", false, "")
+	return cmd
+}
+
+func TestGetLogsQuery(t *testing.T) {
+	now := time.Date(2026, 10, 16, 15, 30, 0, 0, time.Local)
+
+	_, start, end, err := getLogsQuery(newLogsTestCmd("cdn.example.com", ""), now)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if !start.Equal(time.Date(2026, 10, 16, 0, 0, 0, 0, time.Local)) || end.Sub(start) != 24*time.Hour {
+		t.Errorf("默认应查询当天: %s - %s", start, end)
+	}
+
+	_, start, _, err = getLogsQuery(newLogsTestCmd("cdn.example.com", "2026-10-15"), now)
+	if err != nil || !start.Equal(time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local)) {
+		t.Errorf("日期解析错误: %s, %v", start, err)
+	}
+
+	if _, _, _, err := getLogsQuery(newLogsTestCmd("", ""), now); err == nil {
+		t.Error("未指定域名时应返回错误")
+	}
+	if _, _, _, err := getLogsQuery(newLogsTestCmd("cdn.example.com", "2026/10/15"), now); err == nil {
+		t.Error("日期格式错误时应返回错误")
+	}
+}
+
+func TestRunCDNLogsDownloadStdout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/files/00.log" {
+			w.Write([]byte("GET /index.html 200\n"))
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"total": 1,
+			"logs": []map[string]interface{}{
+				{"name": "00.log", "size": 20, "link": "http://" + r.Host + "/files/00.log"},
+			},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	prevSession, prevErr := currentSession, sessionErr
+	t.Cleanup(func() { currentSession, sessionErr = prevSession, prevErr })
+	currentSession = auth.NewSessionFromConfig(&auth.Config{
+		AccessKey: "test-ak",
+		SecretKey: "test-sk",
+		Region:    "cn-north-1",
+		DomainID:  "test-domain-id",
+		Endpoints: map[string]string{auth.ServiceCDN: server.URL, auth.ServiceIAM: server.URL},
+	})
+	sessionErr = nil
+
+	cmd := newLogsTestCmd("cdn.example.com", "2026-10-15")
+	cmd.Flags().Set("stdout", "true")
+	var out bytes.Buffer
+	cmd.SetOut(&out)
+
+	if err := runCDNLogsDownload(cmd, nil); err != nil {
+		t.Fatalf("下载失败: %v", err)
+	}
+	if out.String() != "GET /index.html 200\n" {
+		t.Errorf("标准输出内容错误: %q", out.String())
+	}
+}
@@ -3,8 +3,9 @@ package cmd
 import (
 	"context"
 	"fmt"
+	"io"
+	"os"
 	"strings"
-	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/ygqygq2/hwcctl/internal/auth"
@@ -13,105 +14,183 @@ import (
 	"github.com/ygqygq2/hwcctl/internal/logx"
 	"github.com/ygqygq2/hwcctl/internal/output"
 	"github.com/ygqygq2/hwcctl/internal/retry"
+	"github.com/ygqygq2/hwcctl/internal/utils"
 )
 
+// newRetryer 根据会话配置创建重试器，未启用重试时只执行一次
+func newRetryer(session *auth.Session) *retry.Retryer {
+	config := session.Config()
+	if config.EnableRetry && config.MaxRetries > 0 {
+		retryConfig := retry.DefaultConfig()
+		retryConfig.MaxAttempts = config.MaxRetries + 1 // MaxRetries 是重试次数，需要加1为总尝试次数
+		return retry.NewRetryer(retryConfig)
+	}
+
+	// 不重试，只执行一次
+	return retry.NewRetryer(&retry.Config{
+		MaxAttempts: 1,
+		Strategy:    retry.StrategyFixed,
+		BaseDelay:   0,
+	})
+}
+
+// runWithCDNClient 使用已解析的会话创建 CDN 客户端，并在会话配置的重试策略下执行 fn
+func runWithCDNClient(cmd *cobra.Command, fn func(client *cdn.Client) (interface{}, error)) (interface{}, error) {
+	session, err := getSession(cmd)
+	if err != nil {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("加载配置失败: %v", err))
+	}
+
+	return newRetryer(session).DoWithResult(context.Background(), func() (interface{}, error) {
+		client, err := cdn.NewClient(session)
+		if err != nil {
+			return nil, hwErrors.Wrap(err, "创建 CDN 客户端失败")
+		}
+		return fn(client)
+	})
+}
+
+// collectURLs 汇总 --urls 和 --from-file 指定的 URL，并进行规范化和去重
+func collectURLs(cmd *cobra.Command) ([]string, error) {
+	urls, _ := cmd.Flags().GetStringSlice("urls")
+	fromFile, _ := cmd.Flags().GetString("from-file")
+	inputFormat, _ := cmd.Flags().GetString("input-format")
+
+	if fromFile != "" {
+		var reader io.Reader
+		if fromFile == "-" {
+			reader = cmd.InOrStdin()
+		} else {
+			file, err := os.Open(fromFile)
+			if err != nil {
+				return nil, hwErrors.NewValidationError(fmt.Sprintf("打开 URL 文件失败: %v", err))
+			}
+			defer file.Close()
+			reader = file
+		}
+
+		fileURLs, err := cdn.ReadURLs(reader, strings.ToLower(inputFormat), fromFile)
+		if err != nil {
+			return nil, hwErrors.NewValidationError(err.Error())
+		}
+		logx.Debugf("从 %s 读取到 %d 个 URL", fromFile, len(fileURLs))
+		urls = append(urls, fileURLs...)
+	}
+
+	normalized := cdn.NormalizeURLs(urls)
+	if removed := len(urls) - len(normalized); removed > 0 {
+		logx.Debugf("已移除 %d 个空白或重复的 URL", removed)
+	}
+	return normalized, nil
+}
+
+// addURLInputFlags 为刷新/预热命令添加 URL 输入相关标志
+func addURLInputFlags(cmd *cobra.Command, usage string) {
+	cmd.Flags().StringSlice("urls", []string{}, usage)
+	cmd.Flags().String("from-file", "", "从文件读取 URL 列表，使用 - 表示从标准输入读取")
+	cmd.Flags().String("input-format", cdn.InputFormatAuto, "--from-file 的输入格式："+strings.Join(cdn.InputFormats, "|"))
+	cmd.Flags().Bool("check-domains", false, "提交前校验 URL 的域名是否为当前账号的加速域名")
+}
+
+// checkURLDomains 指定 --check-domains 时校验 URL 的域名归属
+func checkURLDomains(cmd *cobra.Command, client *cdn.Client, urls []string) error {
+	checkDomains, _ := cmd.Flags().GetBool("check-domains")
+	if !checkDomains {
+		return nil
+	}
+
+	logx.Debugf("校验 URL 域名归属")
+	return client.CheckURLDomains(urls)
+}
+
 // refreshCmd 代表 CDN 刷新命令
 var cdnRefreshCmd = &cobra.Command{
-	Use:          "refresh",
-	Short:        "刷新 CDN 缓存",
-	Long:         `刷新指定的 URL 或目录的 CDN 缓存，支持批量刷新。`,
+	Use:   "refresh",
+	Short: "刷新 CDN 缓存",
+	Long: `刷新指定的 URL 或目录的 CDN 缓存，支持批量刷新。
+
+URL 可以通过 --urls 指定，也可以通过 --from-file 从文件或标准输入读取（支持按行分隔的文本、JSON 和 CSV），
+文本中以 # 开头的行视为注释，重复的 URL 会被自动去除。
+
+示例:
+  hwcctl cdn refresh --urls https://example.com/a.js,https://example.com/b.css
+  hwcctl cdn refresh --from-file urls.txt
+  find dist -type f | sed 's#^dist#https://cdn.example.com#' | hwcctl cdn refresh --from-file -`,
 	RunE:         runCDNRefresh,
 	SilenceUsage: true, // 发生错误时不显示用法信息
 }
 
 // preloadCmd 代表 CDN 预热命令
 var cdnPreloadCmd = &cobra.Command{
-	Use:          "preload",
-	Short:        "预热 CDN 缓存",
-	Long:         `预热指定的 URL 到 CDN 边缘节点，提高访问速度。`,
+	Use:   "preload",
+	Short: "预热 CDN 缓存",
+	Long: `预热指定的 URL 到 CDN 边缘节点，提高访问速度。
+
+URL 可以通过 --urls 指定，也可以通过 --from-file 从文件或标准输入读取（支持按行分隔的文本、JSON 和 CSV）。`,
 	RunE:         runCDNPreload,
 	SilenceUsage: true, // 发生错误时不显示用法信息
 }
 
 func runCDNRefresh(cmd *cobra.Command, args []string) error {
 	// 获取参数
-	urls, _ := cmd.Flags().GetStringSlice("urls")
+	urls, err := collectURLs(cmd)
+	if err != nil {
+		return err
+	}
 	refreshType, _ := cmd.Flags().GetString("type")
 
 	if len(urls) == 0 {
 		return hwErrors.NewValidationError("请指定要刷新的 URL 或目录")
 	}
 
+	// 提交前在本地校验 URL，避免无效 URL 消耗配额
+	if err := cdn.ValidateURLs(urls, refreshType); err != nil {
+		return err
+	}
+
 	// 获取输出格式
 	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
 	formatter := output.NewFormatter(outputFormat)
 
-	// 获取配置信息以确定重试策略
-	config, err := auth.LoadConfig("", "", "", "")
+	waitOpts, err := getWaitOptions(cmd, outputFormat)
 	if err != nil {
-		return hwErrors.NewServerError(fmt.Sprintf("加载配置失败: %v", err))
+		return err
 	}
 
-	// 创建重试器 - 根据配置决定是否重试
-	var retryer *retry.Retryer
-	if config.EnableRetry && config.MaxRetries > 0 {
-		retryConfig := retry.DefaultConfig()
-		retryConfig.MaxAttempts = config.MaxRetries + 1 // MaxRetries 是重试次数，需要加1为总尝试次数
-		retryer = retry.NewRetryer(retryConfig)
-	} else {
-		// 不重试，只执行一次
-		retryConfig := &retry.Config{
-			MaxAttempts: 1,
-			Strategy:    retry.StrategyFixed,
-			BaseDelay:   0,
-		}
-		retryer = retry.NewRetryer(retryConfig)
+	// 获取已解析的会话，每个批次使用会话配置的重试策略
+	session, err := getSession(cmd)
+	if err != nil {
+		return hwErrors.NewValidationError(fmt.Sprintf("加载配置失败: %v", err))
+	}
+	batchOpts, err := getBatchOptions(cmd, session)
+	if err != nil {
+		return err
 	}
 
-	logx.Infof("开始刷新 CDN 缓存，类型: %s", refreshType)
-	logx.Infof("待刷新的 URL/目录: %s", strings.Join(urls, ", "))
-
-	// 使用重试机制执行刷新
-	ctx := context.Background()
-	result, err := retryer.DoWithResult(ctx, func() (interface{}, error) {
-		// 创建 CDN 客户端
-		client, err := cdn.NewClient()
-		if err != nil {
-			return nil, hwErrors.NewServerError(fmt.Sprintf("创建 CDN 客户端失败: %v", err))
-		}
+	logx.Infof("开始刷新 CDN 缓存，类型: %s，共 %d 个 URL/目录", refreshType, len(urls))
+	logx.Debugf("待刷新的 URL/目录: %s", strings.Join(urls, ", "))
 
-		// 执行刷新
-		taskId, err := client.RefreshCache(urls, refreshType)
-		if err != nil {
-			return nil, hwErrors.NewServerError(fmt.Sprintf("刷新 CDN 缓存失败: %v", err))
-		}
+	client, err := cdn.NewClient(session)
+	if err != nil {
+		return err
+	}
 
-		return map[string]interface{}{
-			"task_id":    taskId,
-			"type":       "refresh",
-			"urls":       urls,
-			"status":     "submitted",
-			"created_at": time.Now().Format(time.RFC3339),
-		}, nil
-	})
+	if err := checkURLDomains(cmd, client, urls); err != nil {
+		return err
+	}
 
+	// 分批执行刷新，部分批次失败时仍输出已提交的任务
+	result, err := client.RefreshCache(context.Background(), urls, refreshType, batchOpts)
+	if result != nil {
+		logx.Infof("CDN 缓存刷新任务已提交，任务 ID: %s", strings.Join(result.TaskIDs, ", "))
+		printBatchResult(formatter, result, "刷新", waitOpts.Enabled && err == nil)
+	}
 	if err != nil {
-		formatter.PrintError(fmt.Sprintf("CDN 缓存刷新失败: %v", err))
 		return err
 	}
 
-	// 输出结果
-	if outputFormat == "table" || outputFormat == "text" {
-		taskData := result.(map[string]interface{})
-		taskId := taskData["task_id"].(string)
-
-		logx.Infof("CDN 缓存刷新任务已提交，任务 ID: %s", taskId)
-		formatter.PrintSuccess("CDN 缓存刷新任务已提交成功")
-		fmt.Printf("任务 ID: %s\n", taskId)
-		fmt.Printf("可以使用以下命令查询任务状态:\n")
-		fmt.Printf("hwcctl cdn task %s\n", taskId)
-	} else {
-		formatter.Print(result)
+	if waitOpts.Enabled {
+		return waitAndPrintTasks(cmd, result.TaskIDs, outputFormat, waitOpts)
 	}
 
 	return nil
@@ -119,82 +198,63 @@ func runCDNRefresh(cmd *cobra.Command, args []string) error {
 
 func runCDNPreload(cmd *cobra.Command, args []string) error {
 	// 获取参数
-	urls, _ := cmd.Flags().GetStringSlice("urls")
+	urls, err := collectURLs(cmd)
+	if err != nil {
+		return err
+	}
 
 	if len(urls) == 0 {
 		return hwErrors.NewValidationError("请指定要预热的 URL")
 	}
 
+	// 提交前在本地校验 URL，避免无效 URL 消耗配额
+	if err := cdn.ValidateURLs(urls, "url"); err != nil {
+		return err
+	}
+
 	// 获取输出格式
 	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
 	formatter := output.NewFormatter(outputFormat)
 
-	// 获取配置信息以确定重试策略
-	config, err := auth.LoadConfig("", "", "", "")
+	waitOpts, err := getWaitOptions(cmd, outputFormat)
 	if err != nil {
-		return hwErrors.NewServerError(fmt.Sprintf("加载配置失败: %v", err))
+		return err
 	}
 
-	// 创建重试器 - 根据配置决定是否重试
-	var retryer *retry.Retryer
-	if config.EnableRetry && config.MaxRetries > 0 {
-		retryConfig := retry.DefaultConfig()
-		retryConfig.MaxAttempts = config.MaxRetries + 1 // MaxRetries 是重试次数，需要加1为总尝试次数
-		retryer = retry.NewRetryer(retryConfig)
-	} else {
-		// 不重试，只执行一次
-		retryConfig := &retry.Config{
-			MaxAttempts: 1,
-			Strategy:    retry.StrategyFixed,
-			BaseDelay:   0,
-		}
-		retryer = retry.NewRetryer(retryConfig)
+	// 获取已解析的会话，每个批次使用会话配置的重试策略
+	session, err := getSession(cmd)
+	if err != nil {
+		return hwErrors.NewValidationError(fmt.Sprintf("加载配置失败: %v", err))
+	}
+	batchOpts, err := getBatchOptions(cmd, session)
+	if err != nil {
+		return err
 	}
 
-	logx.Infof("开始预热 CDN 缓存")
-	logx.Infof("待预热的 URL: %s", strings.Join(urls, ", "))
-
-	// 使用重试机制执行预热
-	ctx := context.Background()
-	result, err := retryer.DoWithResult(ctx, func() (interface{}, error) {
-		// 创建 CDN 客户端
-		client, err := cdn.NewClient()
-		if err != nil {
-			return nil, hwErrors.NewServerError(fmt.Sprintf("创建 CDN 客户端失败: %v", err))
-		}
+	logx.Infof("开始预热 CDN 缓存，共 %d 个 URL", len(urls))
+	logx.Debugf("待预热的 URL: %s", strings.Join(urls, ", "))
 
-		// 执行预热
-		taskId, err := client.PreloadCache(urls)
-		if err != nil {
-			return nil, hwErrors.NewServerError(fmt.Sprintf("预热 CDN 缓存失败: %v", err))
-		}
+	client, err := cdn.NewClient(session)
+	if err != nil {
+		return err
+	}
 
-		return map[string]interface{}{
-			"task_id":    taskId,
-			"type":       "preload",
-			"urls":       urls,
-			"status":     "submitted",
-			"created_at": time.Now().Format(time.RFC3339),
-		}, nil
-	})
+	if err := checkURLDomains(cmd, client, urls); err != nil {
+		return err
+	}
 
+	// 分批执行预热，部分批次失败时仍输出已提交的任务
+	result, err := client.PreloadCache(context.Background(), urls, batchOpts)
+	if result != nil {
+		logx.Infof("CDN 缓存预热任务已提交，任务 ID: %s", strings.Join(result.TaskIDs, ", "))
+		printBatchResult(formatter, result, "预热", waitOpts.Enabled && err == nil)
+	}
 	if err != nil {
-		formatter.PrintError(fmt.Sprintf("CDN 缓存预热失败: %v", err))
 		return err
 	}
 
-	// 输出结果
-	if outputFormat == "table" || outputFormat == "text" {
-		taskData := result.(map[string]interface{})
-		taskId := taskData["task_id"].(string)
-
-		logx.Infof("CDN 缓存预热任务已提交，任务 ID: %s", taskId)
-		formatter.PrintSuccess("CDN 缓存预热任务已提交成功")
-		fmt.Printf("任务 ID: %s\n", taskId)
-		fmt.Printf("可以使用以下命令查询任务状态:\n")
-		fmt.Printf("hwcctl cdn task %s\n", taskId)
-	} else {
-		formatter.Print(result)
+	if waitOpts.Enabled {
+		return waitAndPrintTasks(cmd, result.TaskIDs, outputFormat, waitOpts)
 	}
 
 	return nil
@@ -202,9 +262,11 @@ func runCDNPreload(cmd *cobra.Command, args []string) error {
 
 // taskCmd 代表查询 CDN 任务状态命令
 var cdnTaskCmd = &cobra.Command{
-	Use:          "task [task-id]",
-	Short:        "查询 CDN 任务状态",
-	Long:         `查询指定任务 ID 的 CDN 刷新或预热任务状态。`,
+	Use:   "task [task-id]",
+	Short: "查询 CDN 任务状态",
+	Long: `查询指定任务 ID 的 CDN 刷新或预热任务状态。
+
+使用 --details 列出任务中每个 URL 的执行状态，配合 --status failed 只查看失败的 URL。`,
 	Args:         cobra.ExactArgs(1),
 	RunE:         runCDNTask,
 	SilenceUsage: true, // 发生错误时不显示用法信息
@@ -217,27 +279,19 @@ func runCDNTask(cmd *cobra.Command, args []string) error {
 	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
 	formatter := output.NewFormatter(outputFormat)
 
-	// 获取配置信息以确定重试策略
-	config, err := auth.LoadConfig("", "", "", "")
-	if err != nil {
-		return hwErrors.NewServerError(fmt.Sprintf("加载配置失败: %v", err))
+	// 指定 --details 或 --status 时查询每个 URL 的执行详情
+	details, _ := cmd.Flags().GetBool("details")
+	status, _ := cmd.Flags().GetString("status")
+	if details || status != "" {
+		return runCDNTaskDetails(cmd, taskId, status, outputFormat)
 	}
 
-	// 创建重试器 - 根据配置决定是否重试
-	var retryer *retry.Retryer
-	if config.EnableRetry && config.MaxRetries > 0 {
-		retryConfig := retry.DefaultConfig()
-		retryConfig.MaxAttempts = config.MaxRetries + 1 // MaxRetries 是重试次数，需要加1为总尝试次数
-		retryer = retry.NewRetryer(retryConfig)
-	} else {
-		// 不重试，只执行一次
-		retryConfig := &retry.Config{
-			MaxAttempts: 1,
-			Strategy:    retry.StrategyFixed,
-			BaseDelay:   0,
-		}
-		retryer = retry.NewRetryer(retryConfig)
+	// 获取已解析的会话，重试策略同样来自会话配置
+	session, err := getSession(cmd)
+	if err != nil {
+		return hwErrors.NewValidationError(fmt.Sprintf("加载配置失败: %v", err))
 	}
+	retryer := newRetryer(session)
 
 	logx.Infof("查询 CDN 任务状态，任务 ID: %s", taskId)
 
@@ -245,37 +299,27 @@ func runCDNTask(cmd *cobra.Command, args []string) error {
 	ctx := context.Background()
 	result, err := retryer.DoWithResult(ctx, func() (interface{}, error) {
 		// 创建 CDN 客户端
-		client, err := cdn.NewClient()
+		client, err := cdn.NewClient(session)
 		if err != nil {
-			return nil, hwErrors.NewServerError(fmt.Sprintf("创建 CDN 客户端失败: %v", err))
+			return nil, hwErrors.Wrap(err, "创建 CDN 客户端失败")
 		}
 
 		// 查询任务状态
 		task, err := client.GetTaskStatus(taskId)
 		if err != nil {
-			return nil, hwErrors.NewServerError(fmt.Sprintf("查询任务状态失败: %v", err))
+			return nil, hwErrors.Wrap(err, "查询任务状态失败")
 		}
 
 		return task, nil
 	})
 
 	if err != nil {
-		formatter.PrintError(fmt.Sprintf("查询 CDN 任务状态失败: %v", err))
 		return err
 	}
 
 	// 输出结果
-	if outputFormat == "table" || outputFormat == "text" {
-		task := result.(*cdn.Task)
-		fmt.Printf("📋 CDN 任务状态\n")
-		fmt.Printf("任务 ID: %s\n", task.ID)
-		fmt.Printf("任务类型: %s\n", task.Type)
-		fmt.Printf("任务状态: %s\n", task.Status)
-		fmt.Printf("创建时间: %s\n", task.CreatedAt)
-		if task.CompletedAt != "" {
-			fmt.Printf("完成时间: %s\n", task.CompletedAt)
-		}
-		fmt.Printf("处理进度: %d%%\n", task.Progress)
+	if formatter.IsHumanReadable() {
+		printTaskDetails(result.(*cdn.Task))
 	} else {
 		formatter.Print(result)
 	}
@@ -283,6 +327,66 @@ func runCDNTask(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
+func runCDNTaskDetails(cmd *cobra.Command, taskId, status, outputFormat string) error {
+	formatter := output.NewFormatter(outputFormat)
+
+	status = strings.ToLower(strings.TrimSpace(status))
+	if status != "" && !utils.StringSliceContains(cdn.URLStatuses, status) {
+		return hwErrors.NewValidationError(fmt.Sprintf("不支持的 URL 状态: %s，支持的状态: %s",
+			status, strings.Join(cdn.URLStatuses, ", ")))
+	}
+	pageSize, _ := cmd.Flags().GetInt("page-size")
+
+	session, err := getSession(cmd)
+	if err != nil {
+		return hwErrors.NewValidationError(fmt.Sprintf("加载配置失败: %v", err))
+	}
+	retryer := newRetryer(session)
+
+	logx.Infof("查询 CDN 任务详情，任务 ID: %s", taskId)
+
+	ctx := context.Background()
+	result, err := retryer.DoWithResult(ctx, func() (interface{}, error) {
+		client, err := cdn.NewClient(session)
+		if err != nil {
+			return nil, hwErrors.Wrap(err, "创建 CDN 客户端失败")
+		}
+
+		taskDetails, err := client.GetTaskDetails(taskId, status, pageSize)
+		if err != nil {
+			return nil, hwErrors.Wrap(err, "查询任务详情失败")
+		}
+
+		return taskDetails, nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	taskDetails := result.(*cdn.TaskDetails)
+	if formatter.IsHumanReadable() {
+		printTaskDetails(&taskDetails.Task)
+		fmt.Printf("URL 统计: 共 %d 个，成功 %d 个，失败 %d 个\n\n", taskDetails.Total, taskDetails.Succeed, taskDetails.Failed)
+		return formatter.Print(taskDetails.URLs)
+	}
+
+	return formatter.Print(taskDetails)
+}
+
+// printTaskDetails 以文本形式打印任务状态
+func printTaskDetails(task *cdn.Task) {
+	fmt.Printf("📋 CDN 任务状态\n")
+	fmt.Printf("任务 ID: %s\n", task.ID)
+	fmt.Printf("任务类型: %s\n", task.Type)
+	fmt.Printf("任务状态: %s\n", task.Status)
+	fmt.Printf("创建时间: %s\n", task.CreatedAt)
+	if task.CompletedAt != "" {
+		fmt.Printf("完成时间: %s\n", task.CompletedAt)
+	}
+	fmt.Printf("处理进度: %d%%\n", task.Progress)
+}
+
 func init() {
 	// 添加 CDN 子命令
 	cdnCmd.AddCommand(cdnRefreshCmd)
@@ -290,11 +394,18 @@ func init() {
 	cdnCmd.AddCommand(cdnTaskCmd)
 
 	// CDN 刷新命令的标志
-	cdnRefreshCmd.Flags().StringSlice("urls", []string{}, "要刷新的 URL 或目录列表（必需）")
+	addURLInputFlags(cdnRefreshCmd, "要刷新的 URL 或目录列表")
 	cdnRefreshCmd.Flags().String("type", "url", "刷新类型：url（文件）或 directory（目录）")
-	cdnRefreshCmd.MarkFlagRequired("urls")
+	addBatchFlags(cdnRefreshCmd, fmt.Sprintf("文件 %d，目录 %d", cdn.MaxRefreshURLsPerRequest, cdn.MaxRefreshDirectoriesPerRequest))
+	addWaitFlags(cdnRefreshCmd)
 
 	// CDN 预热命令的标志
-	cdnPreloadCmd.Flags().StringSlice("urls", []string{}, "要预热的 URL 列表（必需）")
-	cdnPreloadCmd.MarkFlagRequired("urls")
+	addURLInputFlags(cdnPreloadCmd, "要预热的 URL 列表")
+	addBatchFlags(cdnPreloadCmd, fmt.Sprintf("%d", cdn.MaxPreloadURLsPerRequest))
+	addWaitFlags(cdnPreloadCmd)
+
+	// CDN 任务查询命令的标志
+	cdnTaskCmd.Flags().Bool("details", false, "列出任务中每个 URL 的执行状态")
+	cdnTaskCmd.Flags().String("status", "", "按 URL 状态过滤详情（隐含 --details）："+strings.Join(cdn.URLStatuses, "|"))
+	cdnTaskCmd.Flags().Int("page-size", cdn.DefaultTaskDetailsPageSize, "查询详情时每页获取的 URL 数量，会自动翻页获取全部 URL")
 }
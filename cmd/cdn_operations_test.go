@@ -1,10 +1,15 @@
 package cmd
 
 import (
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
 	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/auth"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
 )
 
 func TestCDNRefreshCmd(t *testing.T) {
@@ -164,3 +169,154 @@ func TestCDNPreloadCommandFlags(t *testing.T) {
 		t.Error("preload命令应该有urls标志")
 	}
 }
+
+func TestCDNTaskDetailsFlags(t *testing.T) {
+	for _, name := range []string{"details", "status", "page-size"} {
+		if cdnTaskCmd.Flags().Lookup(name) == nil {
+			t.Errorf("task命令应该有%s标志", name)
+		}
+	}
+}
+
+func TestRunCDNTaskDetailsInvalidStatus(t *testing.T) {
+	rootCmd := &cobra.Command{}
+	rootCmd.PersistentFlags().String("output", "table", "Output format")
+
+	cmd := &cobra.Command{}
+	cmd.Flags().Bool("details", false, "")
+	cmd.Flags().String("status", "", "")
+	cmd.Flags().Int("page-size", 100, "")
+	rootCmd.AddCommand(cmd)
+
+	cmd.Flags().Set("status", "unknown")
+	err := runCDNTask(cmd, []string{"task-id"})
+	if err == nil || !strings.Contains(err.Error(), "不支持的 URL 状态") {
+		t.Errorf("期望返回状态校验错误，实际为: %v", err)
+	}
+}
+
+// newURLInputTestCmd 创建带有 URL 输入标志的测试命令
+func newURLInputTestCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	addURLInputFlags(cmd, "URLs")
+	return cmd
+}
+
+func TestCollectURLsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "urls.txt")
+	content := "# 需要刷新的资源\nhttps://example.com/a.js\nhttps://example.com/b.css\n"
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("写入测试文件失败: %v", err)
+	}
+
+	cmd := newURLInputTestCmd()
+	cmd.Flags().Set("urls", "https://EXAMPLE.com/a.js,https://example.com/c.png")
+	cmd.Flags().Set("from-file", path)
+
+	urls, err := collectURLs(cmd)
+	if err != nil {
+		t.Fatalf("汇总 URL 失败: %v", err)
+	}
+	want := []string{"https://example.com/a.js", "https://example.com/c.png", "https://example.com/b.css"}
+	if strings.Join(urls, ",") != strings.Join(want, ",") {
+		t.Errorf("期望 %v，实际为 %v", want, urls)
+	}
+}
+
+func TestCollectURLsFromStdin(t *testing.T) {
+	cmd := newURLInputTestCmd()
+	cmd.SetIn(strings.NewReader(`{"urls": ["https://example.com/a.js", "https://example.com/a.js"]}`))
+	cmd.Flags().Set("from-file", "-")
+
+	urls, err := collectURLs(cmd)
+	if err != nil {
+		t.Fatalf("汇总 URL 失败: %v", err)
+	}
+	if len(urls) != 1 || urls[0] != "https://example.com/a.js" {
+		t.Errorf("期望去重后只有 1 个 URL，实际为 %v", urls)
+	}
+}
+
+func TestCollectURLsErrors(t *testing.T) {
+	cmd := newURLInputTestCmd()
+	cmd.Flags().Set("from-file", filepath.Join(t.TempDir(), "missing.txt"))
+	if _, err := collectURLs(cmd); err == nil {
+		t.Error("期望文件不存在时返回错误")
+	}
+
+	cmd = newURLInputTestCmd()
+	cmd.SetIn(strings.NewReader("https://example.com/a.js"))
+	cmd.Flags().Set("from-file", "-")
+	cmd.Flags().Set("input-format", "xml")
+	if _, err := collectURLs(cmd); err == nil {
+		t.Error("期望不支持的输入格式返回错误")
+	}
+}
+
+func TestCDNBatchFlags(t *testing.T) {
+	for _, cmd := range []*cobra.Command{cdnRefreshCmd, cdnPreloadCmd} {
+		for _, name := range []string{"batch-size", "concurrency", "skip-quota-check"} {
+			if cmd.Flags().Lookup(name) == nil {
+				t.Errorf("%s命令应该有%s标志", cmd.Name(), name)
+			}
+		}
+	}
+}
+
+func TestGetBatchOptionsValidation(t *testing.T) {
+	cmd := &cobra.Command{}
+	addBatchFlags(cmd, "1000")
+	cmd.Flags().Set("concurrency", "0")
+	if _, err := getBatchOptions(cmd, nil); err == nil {
+		t.Error("期望 --concurrency 为 0 时返回错误")
+	}
+
+	cmd = &cobra.Command{}
+	addBatchFlags(cmd, "1000")
+	cmd.Flags().Set("batch-size", "-1")
+	if _, err := getBatchOptions(cmd, nil); err == nil {
+		t.Error("期望 --batch-size 为负数时返回错误")
+	}
+}
+
+func TestRunCDNRefreshInvalidURL(t *testing.T) {
+	rootCmd := &cobra.Command{}
+	rootCmd.PersistentFlags().String("output", "table", "Output format")
+
+	cmd := &cobra.Command{}
+	addURLInputFlags(cmd, "URLs")
+	cmd.Flags().String("type", "directory", "Refresh type")
+	rootCmd.AddCommand(cmd)
+
+	cmd.Flags().Set("urls", "https://example.com/static")
+	err := runCDNRefresh(cmd, []string{})
+	if err == nil || !strings.Contains(err.Error(), "必须以 / 结尾") {
+		t.Errorf("期望目录 URL 校验失败，实际为 %v", err)
+	}
+}
+
+func TestRunWithCDNClientKeepsErrorType(t *testing.T) {
+	prevSession, prevErr := currentSession, sessionErr
+	t.Cleanup(func() { currentSession, sessionErr = prevSession, prevErr })
+	currentSession = auth.NewSessionFromConfig(&auth.Config{
+		AccessKey:   "test-ak",
+		SecretKey:   "test-sk",
+		Region:      "cn-north-1",
+		DomainID:    "test-domain-id",
+		EnableRetry: true,
+		MaxRetries:  3,
+	})
+	sessionErr = nil
+
+	attempts := 0
+	_, err := runWithCDNClient(&cobra.Command{}, func(client *cdn.Client) (interface{}, error) {
+		attempts++
+		return nil, hwErrors.Wrap(hwErrors.NewNotFoundError("www.example.com"), "查询加速域名详情失败")
+	})
+	if code := hwErrors.ExitCode(err); code != hwErrors.ExitCodeNotFound {
+		t.Errorf("期望退出码 %d，实际为 %d（%v）", hwErrors.ExitCodeNotFound, code, err)
+	}
+	if attempts != 1 {
+		t.Errorf("不可重试的错误不应重试，实际执行 %d 次", attempts)
+	}
+}
@@ -0,0 +1,229 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/output"
+)
+
+// cdnPlanCmd 代表生成加速域名变更计划命令
+var cdnPlanCmd = &cobra.Command{
+	Use:   "plan",
+	Short: "比较多个加速域名的期望配置与线上配置",
+	Long: `读取包含多个加速域名的配置文件，查询线上配置并输出差异，不做任何修改。
+
+配置文件格式:
+  domains:
+    - domain: a.example.com
+      configs:
+        cache_rules: [...]
+    - domain: b.example.com
+      configs:
+        referer: {...}
+
+每个域名的 configs 与 cdn domain config get 的输出格式一致，未出现的配置项不做比较。
+使用 --detect-drift 时，存在差异则以退出码 11 退出，适合定时检查配置漂移。
+
+示例:
+  hwcctl cdn plan -f domains.yaml
+  hwcctl cdn plan -f domains.yaml --output json
+  hwcctl cdn plan -f domains.yaml --detect-drift`,
+	Args:         cobra.NoArgs,
+	RunE:         runCDNPlan,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// cdnApplyCmd 代表执行加速域名变更计划命令
+var cdnApplyCmd = &cobra.Command{
+	Use:   "apply",
+	Short: "将多个加速域名的线上配置更新为期望配置",
+	Long: `生成与 plan 相同的变更计划，确认后只更新存在差异的域名和配置项。
+
+执行前会要求确认，使用 --yes 跳过确认。从标准输入读取配置文件时必须指定 --yes。
+
+示例:
+  hwcctl cdn apply -f domains.yaml
+  hwcctl cdn apply -f domains.yaml --yes --concurrency 5`,
+	Args:         cobra.NoArgs,
+	RunE:         runCDNApply,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// planRow 变更计划汇总表格中的一行
+type planRow struct {
+	Domain   string `table:"域名"`
+	Changes  int    `table:"变更配置项"`
+	Sections string `table:"配置项"`
+}
+
+// applyOutput 非表格格式下 apply 的输出
+type applyOutput struct {
+	Plan    *cdn.Plan         `json:"plan" yaml:"plan"`
+	Results []cdn.ApplyResult `json:"results" yaml:"results"`
+}
+
+// addPlanFlags 为 plan/apply 命令添加公共标志
+func addPlanFlags(cmd *cobra.Command) {
+	cmd.Flags().StringP("file", "f", "", "域名配置文件路径（YAML 或 JSON），- 表示从标准输入读取")
+	cmd.Flags().Int("concurrency", cdn.DefaultBatchConcurrency, "同时处理的域名数量")
+}
+
+// buildCDNPlan 读取配置文件并生成变更计划
+func buildCDNPlan(cmd *cobra.Command) (*cdn.Client, *cdn.Plan, cdn.PlanOptions, error) {
+	opts := cdn.PlanOptions{}
+	file, _ := cmd.Flags().GetString("file")
+	if file == "" {
+		return nil, nil, opts, hwErrors.NewValidationError("请使用 --file 指定域名配置文件")
+	}
+	opts.Concurrency, _ = cmd.Flags().GetInt("concurrency")
+	if opts.Concurrency <= 0 {
+		return nil, nil, opts, hwErrors.NewValidationError("--concurrency 必须大于 0")
+	}
+
+	data, err := readConfigFile(cmd, file)
+	if err != nil {
+		return nil, nil, opts, err
+	}
+	desired, err := cdn.ParseDomainsFile(data)
+	if err != nil {
+		return nil, nil, opts, err
+	}
+
+	session, err := getSession(cmd)
+	if err != nil {
+		return nil, nil, opts, hwErrors.NewValidationError(fmt.Sprintf("加载配置失败: %v", err))
+	}
+	opts.Retryer = newRetryer(session)
+
+	client, err := cdn.NewClient(session)
+	if err != nil {
+		return nil, nil, opts, err
+	}
+
+	logx.Infof("比较 %d 个加速域名的配置", len(desired))
+	plan, err := client.PlanDomainConfigs(context.Background(), desired, opts)
+	if err != nil {
+		return nil, nil, opts, err
+	}
+
+	for _, diff := range plan.Domains {
+		for _, section := range diff.Ignored {
+			logx.Warnf("加速域名 %s 的配置项 %s 不支持更新，已忽略", diff.Domain, section)
+		}
+	}
+	return client, plan, opts, nil
+}
+
+// printPlan 打印每个存在变更的域名的差异和汇总表格
+func printPlan(formatter *output.Formatter, plan *cdn.Plan) {
+	changed := plan.ChangedDomains()
+	for _, diff := range changed {
+		printConfigDiff(os.Stdout, diff)
+		fmt.Println()
+	}
+
+	rows := make([]planRow, 0, len(plan.Domains))
+	for _, diff := range plan.Domains {
+		sections := make([]string, 0, len(diff.Changes))
+		for _, change := range diff.Changes {
+			sections = append(sections, change.Section)
+		}
+		rows = append(rows, planRow{
+			Domain:   diff.Domain,
+			Changes:  len(diff.Changes),
+			Sections: strings.Join(sections, ","),
+		})
+	}
+	formatter.Print(rows)
+
+	fmt.Printf("\n共 %d 个域名，%d 个需要更新，%d 个与线上一致\n",
+		len(plan.Domains), len(changed), len(plan.Domains)-len(changed))
+}
+
+func runCDNPlan(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+	formatter := output.NewFormatter(outputFormat)
+	detectDrift, _ := cmd.Flags().GetBool("detect-drift")
+
+	_, plan, _, err := buildCDNPlan(cmd)
+	if err != nil {
+		return err
+	}
+
+	if formatter.IsHumanReadable() {
+		printPlan(formatter, plan)
+	} else if err := formatter.Print(plan); err != nil {
+		return err
+	}
+
+	if detectDrift && plan.HasChanges() {
+		return hwErrors.NewDriftDetectedError(fmt.Sprintf("%d 个加速域名的线上配置与期望配置不一致", len(plan.ChangedDomains())))
+	}
+	return nil
+}
+
+func runCDNApply(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+	formatter := output.NewFormatter(outputFormat)
+	tableOutput := formatter.IsHumanReadable()
+
+	file, _ := cmd.Flags().GetString("file")
+	yes, _ := cmd.Flags().GetBool("yes")
+	if file == "-" && !yes {
+		return hwErrors.NewValidationError("从标准输入读取配置文件时无法确认，请同时指定 --yes")
+	}
+
+	client, plan, opts, err := buildCDNPlan(cmd)
+	if err != nil {
+		return err
+	}
+
+	if tableOutput {
+		printPlan(formatter, plan)
+	}
+	if !plan.HasChanges() {
+		if !tableOutput {
+			return formatter.Print(applyOutput{Plan: plan, Results: []cdn.ApplyResult{}})
+		}
+		return nil
+	}
+
+	changed := len(plan.ChangedDomains())
+	if !yes && !confirmAction(cmd, fmt.Sprintf("确认更新 %d 个加速域名的配置？", changed)) {
+		return hwErrors.NewValidationError("已取消更新")
+	}
+
+	logx.Infof("更新 %d 个加速域名的配置", changed)
+	results, err := client.ApplyPlan(context.Background(), plan, opts)
+	if tableOutput {
+		fmt.Println()
+		formatter.Print(results)
+	} else {
+		formatter.Print(applyOutput{Plan: plan, Results: results})
+	}
+	if err != nil {
+		return err
+	}
+
+	if tableOutput {
+		formatter.PrintSuccess(fmt.Sprintf("%d 个加速域名配置更新成功", changed))
+	}
+	return nil
+}
+
+func init() {
+	cdnCmd.AddCommand(cdnPlanCmd, cdnApplyCmd)
+
+	addPlanFlags(cdnPlanCmd)
+	cdnPlanCmd.Flags().Bool("detect-drift", false, "存在差异时以非零退出码退出，用于检测配置漂移")
+
+	addPlanFlags(cdnApplyCmd)
+	cdnApplyCmd.Flags().BoolP("yes", "y", false, "跳过更新确认")
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/auth"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+)
+
+func TestCDNPlanCommands(t *testing.T) {
+	for _, name := range []string{"plan", "apply"} {
+		found := false
+		for _, sub := range cdnCmd.Commands() {
+			if sub.Name() == name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			t.Errorf("cdn命令应该有%s子命令", name)
+		}
+	}
+
+	if cdnPlanCmd.Flags().Lookup("detect-drift") == nil {
+		t.Error("plan命令应该有--detect-drift标志")
+	}
+	if cdnApplyCmd.Flags().Lookup("yes") == nil {
+		t.Error("apply命令应该有--yes标志")
+	}
+}
+
+// newPlanTestCmd 创建带有 plan 标志的测试命令，并将会话指向模拟的 CDN 服务
+func newPlanTestCmd(t *testing.T, liveStatus string) *cobra.Command {
+	t.Helper()
+	return newPlanTestCmdWithConfigs(t, map[string]interface{}{
+		"compress": map[string]interface{}{"status": liveStatus},
+	}, "compress:\n        status: \"on\"\n")
+}
+
+// newPlanTestCmdWithConfigs 创建测试命令，模拟的 CDN 服务返回 live 作为线上配置
+// desired 为 a.example.com 的 configs 内容，按 8 个空格缩进
+func newPlanTestCmdWithConfigs(t *testing.T, live map[string]interface{}, desired string) *cobra.Command {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet {
+			t.Errorf("plan 不应修改配置: %s %s", r.Method, r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]interface{}{"configs": live})
+	}))
+	t.Cleanup(server.Close)
+
+	prevSession, prevErr := currentSession, sessionErr
+	t.Cleanup(func() { currentSession, sessionErr = prevSession, prevErr })
+	currentSession = auth.NewSessionFromConfig(&auth.Config{
+		AccessKey: "test-ak",
+		SecretKey: "test-sk",
+		Region:    "cn-north-1",
+		DomainID:  "test-domain-id",
+		Endpoints: map[string]string{auth.ServiceCDN: server.URL, auth.ServiceIAM: server.URL},
+	})
+	sessionErr = nil
+
+	path := filepath.Join(t.TempDir(), "domains.yaml")
+	content := "domains:\n  - domain: a.example.com\n    configs:\n      " + desired
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("写入文件失败: %v", err)
+	}
+
+	cmd := &cobra.Command{}
+	cmd.PersistentFlags().String("output", "json", "")
+	addPlanFlags(cmd)
+	cmd.Flags().Bool("detect-drift", true, "")
+	cmd.Flags().Set("file", path)
+	return cmd
+}
+
+func TestRunCDNPlanDetectDrift(t *testing.T) {
+	err := runCDNPlan(newPlanTestCmd(t, "off"), nil)
+	if code := hwErrors.ExitCode(err); code != hwErrors.ExitCodeDrift {
+		t.Errorf("存在漂移时期望退出码 %d，实际为 %d（%v）", hwErrors.ExitCodeDrift, code, err)
+	}
+
+	if err := runCDNPlan(newPlanTestCmd(t, "on"), nil); err != nil {
+		t.Errorf("无漂移时期望成功，实际为 %v", err)
+	}
+}
+
+func TestRunCDNPlanPartialSectionNoDrift(t *testing.T) {
+	// 线上配置包含接口填充的默认值，期望配置只指定部分字段时不应视为漂移
+	live := map[string]interface{}{
+		"sources": []map[string]interface{}{
+			{"origin_type": "domain", "origin_addr": "origin.example.com", "priority": 70, "weight": 1,
+				"host_name": "a.example.com", "obs_web_hosting_status": "off"},
+		},
+		"compress": map[string]interface{}{"status": "on", "type": "gzip", "file_type": ".js,.html,.css"},
+	}
+	desired := "compress:\n        status: \"on\"\n" +
+		"      sources:\n        - origin_type: domain\n          origin_addr: origin.example.com\n          priority: 70\n"
+
+	if err := runCDNPlan(newPlanTestCmdWithConfigs(t, live, desired), nil); err != nil {
+		t.Errorf("期望配置为线上配置的子集时不应检测到漂移，实际为 %v", err)
+	}
+}
+
+func TestRunCDNApplyStdinRequiresYes(t *testing.T) {
+	cmd := &cobra.Command{}
+	addPlanFlags(cmd)
+	cmd.Flags().Bool("yes", false, "")
+	cmd.Flags().Set("file", "-")
+
+	if err := runCDNApply(cmd, nil); err == nil {
+		t.Error("从标准输入读取且未指定 --yes 时应返回错误")
+	}
+}
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/output"
+	"github.com/ygqygq2/hwcctl/internal/utils"
+)
+
+// statsIntervals 支持的 --interval 取值
+var statsIntervals = map[string]time.Duration{
+	"5m": cdn.StatsInterval5Min,
+	"1h": cdn.StatsIntervalHour,
+	"1d": cdn.StatsIntervalDay,
+}
+
+// statsMetricNames 统计指标在表格中的列名
+var statsMetricNames = map[string]string{
+	cdn.MetricFlux:       "流量",
+	cdn.MetricBandwidth:  "带宽峰值",
+	cdn.MetricReqNum:     "请求数",
+	cdn.MetricHitNum:     "命中数",
+	cdn.MetricHitRate:    "命中率",
+	cdn.MetricBSFlux:     "回源流量",
+	cdn.MetricBSBW:       "回源带宽",
+	cdn.MetricBSNum:      "回源请求数",
+	cdn.MetricBSFailNum:  "回源失败数",
+	cdn.MetricHTTPCode2x: "2xx",
+	cdn.MetricHTTPCode3x: "3xx",
+	cdn.MetricHTTPCode4x: "4xx",
+	cdn.MetricHTTPCode5x: "5xx",
+}
+
+// cdnStatsCmd 代表 CDN 统计数据命令
+var cdnStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "查询 CDN 流量、带宽和请求统计",
+	Long: `查询 CDN 加速域名的流量、带宽、请求数、命中率和状态码统计。
+
+不指定 --interval 时输出时间范围内的汇总数据，指定后按间隔输出明细数据。
+支持的指标: ` + strings.Join(cdn.StatsMetrics, ", ") + `
+指标别名: bandwidth（bw）、status_codes（http_code_2xx 至 http_code_5xx）
+
+--group-by region 按省份分组（仅中国大陆），--group-by carrier 按运营商分组，
+这两种分组只支持 flux、bw、req_num 和 http_code_* 指标。
+
+使用 --output csv 或 --output tsv 输出原始数值，便于导入表格软件。
+
+示例:
+  hwcctl cdn stats --domain www.example.com --since 24h
+  hwcctl cdn stats --domain a.example.com --domain b.example.com --group-by domain
+  hwcctl cdn stats --metric flux,hit_rate,status_codes --since 7d --interval 1d
+  hwcctl cdn stats --since 2026-10-15 --until 2026-10-16 --interval 1h --output csv > stats.csv`,
+	Args:         cobra.NoArgs,
+	RunE:         runCDNStats,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// getStatsOptions 从命令标志中读取统计查询条件
+func getStatsOptions(cmd *cobra.Command, now time.Time) (cdn.StatsOptions, error) {
+	opts := cdn.StatsOptions{}
+
+	var err error
+	if opts.Start, opts.End, err = parseTimeRange(cmd, now, "24h"); err != nil {
+		return opts, err
+	}
+
+	opts.Domains, _ = cmd.Flags().GetStringSlice("domain")
+	opts.Domains = utils.RemoveEmptyStrings(opts.Domains)
+
+	metrics, _ := cmd.Flags().GetStringSlice("metric")
+	if opts.Metrics, err = cdn.ExpandStatsMetrics(metrics); err != nil {
+		return opts, err
+	}
+
+	if interval, _ := cmd.Flags().GetString("interval"); interval != "" {
+		d, ok := statsIntervals[strings.ToLower(interval)]
+		if !ok {
+			return opts, hwErrors.NewValidationError(fmt.Sprintf("不支持的统计间隔: %s，支持: 5m、1h、1d", interval))
+		}
+		opts.Interval = d
+	}
+
+	opts.GroupBy, _ = cmd.Flags().GetString("group-by")
+	opts.GroupBy = strings.ToLower(strings.TrimSpace(opts.GroupBy))
+	if opts.GroupBy != "" && !utils.StringSliceContains(cdn.StatsGroupBys, opts.GroupBy) {
+		return opts, hwErrors.NewValidationError(fmt.Sprintf("不支持的分组方式: %s，支持的方式: %s",
+			opts.GroupBy, strings.Join(cdn.StatsGroupBys, ", ")))
+	}
+
+	opts.ServiceArea, _ = cmd.Flags().GetString("service-area")
+	return opts, nil
+}
+
+// formatStatsValue 按指标类型格式化数值
+func formatStatsValue(metric string, value float64) string {
+	switch metric {
+	case cdn.MetricFlux, cdn.MetricBSFlux:
+		return utils.FormatBytes(value)
+	case cdn.MetricBandwidth, cdn.MetricBSBW:
+		return utils.FormatBitRate(value)
+	case cdn.MetricHitRate:
+		return fmt.Sprintf("%.2f%%", value)
+	default:
+		return utils.FormatCount(value)
+	}
+}
+
+// statsHeader 返回统计表格的表头，机器可读时使用指标名称
+func statsHeader(result *cdn.StatsResult, readable bool) []string {
+	var header []string
+	if result.Interval > 0 {
+		if readable {
+			header = append(header, "时间")
+		} else {
+			header = append(header, "time")
+		}
+	}
+	if result.GroupBy != "" {
+		if readable {
+			header = append(header, "分组")
+		} else {
+			header = append(header, result.GroupBy)
+		}
+	}
+	for _, metric := range result.Metrics {
+		if name, ok := statsMetricNames[metric]; ok && readable {
+			header = append(header, name)
+		} else {
+			header = append(header, metric)
+		}
+	}
+	return header
+}
+
+// statsRecord 返回一行统计数据，readable 为 true 时数值按单位格式化
+func statsRecord(result *cdn.StatsResult, row cdn.StatsRow, readable bool) []string {
+	var record []string
+	if result.Interval > 0 {
+		record = append(record, row.Time)
+	}
+	if result.GroupBy != "" {
+		record = append(record, row.Group)
+	}
+	for _, metric := range result.Metrics {
+		value := row.Values[metric]
+		if readable {
+			record = append(record, formatStatsValue(metric, value))
+		} else {
+			record = append(record, strconv.FormatFloat(value, 'f', -1, 64))
+		}
+	}
+	return record
+}
+
+// printStatsTable 以表格形式打印统计数据
+func printStatsTable(w io.Writer, result *cdn.StatsResult) {
+	if len(result.Rows) == 0 {
+		fmt.Fprintln(w, "没有找到数据")
+		return
+	}
+
+	tw := tabwriter.NewWriter(w, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, strings.Join(statsHeader(result, true), "\t"))
+	for _, row := range result.Rows {
+		fmt.Fprintln(tw, strings.Join(statsRecord(result, row, true), "\t"))
+	}
+	tw.Flush()
+
+	fmt.Fprintf(w, "\n统计时间: %s - %s\n", result.Start, result.End)
+}
+
+// writeStatsRecords 以 CSV 或 TSV 格式输出统计数据的原始数值，每个指标一列
+func writeStatsRecords(w io.Writer, formatter *output.Formatter, result *cdn.StatsResult) error {
+	records := make([][]string, 0, len(result.Rows))
+	for _, row := range result.Rows {
+		records = append(records, statsRecord(result, row, false))
+	}
+	return formatter.WriteRecords(w, statsHeader(result, false), records)
+}
+
+func runCDNStats(cmd *cobra.Command, args []string) error {
+	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+	formatter := output.NewFormatter(outputFormat)
+
+	opts, err := getStatsOptions(cmd, time.Now())
+	if err != nil {
+		return err
+	}
+
+	logx.Infof("查询 CDN 统计数据: %s", strings.Join(opts.Metrics, ","))
+	result, err := runWithCDNClient(cmd, func(client *cdn.Client) (interface{}, error) {
+		stats, err := client.GetStats(opts)
+		if err != nil {
+			return nil, hwErrors.Wrap(err, "查询统计数据失败")
+		}
+		return stats, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	stats := result.(*cdn.StatsResult)
+	switch {
+	case formatter.IsDelimited() && !formatter.HasQuery():
+		return writeStatsRecords(os.Stdout, formatter, stats)
+	case formatter.IsHumanReadable():
+		printStatsTable(os.Stdout, stats)
+		return nil
+	default:
+		return formatter.Print(stats)
+	}
+}
+
+func init() {
+	cdnCmd.AddCommand(cdnStatsCmd)
+
+	cdnStatsCmd.Flags().StringSlice("domain", []string{}, "加速域名，可指定多次，默认查询全部域名")
+	cdnStatsCmd.Flags().StringSlice("metric", []string{cdn.MetricFlux, cdn.MetricBandwidth, cdn.MetricReqNum}, "统计指标，可指定多个")
+	cdnStatsCmd.Flags().String("interval", "", "明细数据的时间间隔：5m|1h|1d，不指定时输出汇总数据")
+	cdnStatsCmd.Flags().String("group-by", "", "分组方式："+strings.Join(cdn.StatsGroupBys, "|"))
+	cdnStatsCmd.Flags().String("since", "24h", "起始时间，支持相对时间（如 24h、7d）或绝对时间")
+	cdnStatsCmd.Flags().String("until", "now", "结束时间，支持相对时间（如 1h）或绝对时间")
+	cdnStatsCmd.Flags().String("service-area", "", "服务区域：mainland_china|outside_mainland_china")
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+	"github.com/ygqygq2/hwcctl/internal/output"
+)
+
+// newStatsTestCmd 创建带有统计查询标志的测试命令
+func newStatsTestCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().StringSlice("domain", []string{}, "")
+	cmd.Flags().StringSlice("metric", []string{"flux", "bw", "req_num"}, "")
+	cmd.Flags().String("interval", "", "")
+	cmd.Flags().String("group-by", "", "")
+	cmd.Flags().String("since", "24h", "")
+	cmd.Flags().String("until", "now", "")
+	cmd.Flags().String("service-area", "", "")
+	return cmd
+}
+
+func TestGetStatsOptions(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+	cmd := newStatsTestCmd()
+	cmd.Flags().Set("domain", "a.example.com,b.example.com")
+	cmd.Flags().Set("metric", "flux,status_codes")
+	cmd.Flags().Set("interval", "1h")
+	cmd.Flags().Set("group-by", "Domain")
+	cmd.Flags().Set("since", "2d")
+
+	opts, err := getStatsOptions(cmd, now)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if len(opts.Domains) != 2 || len(opts.Metrics) != 5 || opts.Interval != time.Hour || opts.GroupBy != "domain" {
+		t.Errorf("查询条件错误: %+v", opts)
+	}
+	if !opts.Start.Equal(now.Add(-48*time.Hour)) || !opts.End.Equal(now) {
+		t.Errorf("时间范围错误: %s - %s", opts.Start, opts.End)
+	}
+
+	invalid := map[string]string{
+		"interval": "10m",
+		"group-by": "city",
+		"metric":   "qps",
+		"since":    "yesterday",
+	}
+	for flag, value := range invalid {
+		cmd := newStatsTestCmd()
+		cmd.Flags().Set(flag, value)
+		if _, err := getStatsOptions(cmd, now); err == nil {
+			t.Errorf("期望 --%s=%s 校验失败", flag, value)
+		}
+	}
+}
+
+func TestPrintStats(t *testing.T) {
+	result := &cdn.StatsResult{
+		Metrics:  []string{cdn.MetricFlux, cdn.MetricHitRate},
+		GroupBy:  cdn.StatsGroupByDomain,
+		Interval: 3600,
+		Rows: []cdn.StatsRow{
+			{Time: "2026-10-15 00:00:00", Group: "a.example.com", Values: map[string]float64{"flux": 1536, "hit_rate": 95.5}},
+		},
+	}
+
+	var table bytes.Buffer
+	printStatsTable(&table, result)
+	for _, want := range []string{"时间", "分组", "流量", "命中率", "1.50 KB", "95.50%"} {
+		if !strings.Contains(table.String(), want) {
+			t.Errorf("表格输出缺少 %q:\n%s", want, table.String())
+		}
+	}
+
+	var csv bytes.Buffer
+	if err := writeStatsRecords(&csv, output.NewFormatter("csv"), result); err != nil {
+		t.Fatalf("输出 CSV 失败: %v", err)
+	}
+	expected := "time,domain,flux,hit_rate\n2026-10-15 00:00:00,a.example.com,1536,95.5\n"
+	if csv.String() != expected {
+		t.Errorf("CSV 输出错误:\n%s", csv.String())
+	}
+
+	var tsv bytes.Buffer
+	if err := writeStatsRecords(&tsv, output.NewFormatter("tsv"), result); err != nil {
+		t.Fatalf("输出 TSV 失败: %v", err)
+	}
+	expected = "time\tdomain\tflux\thit_rate\n2026-10-15 00:00:00\ta.example.com\t1536\t95.5\n"
+	if tsv.String() != expected {
+		t.Errorf("TSV 输出错误:\n%s", tsv.String())
+	}
+}
@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/output"
+	"github.com/ygqygq2/hwcctl/internal/utils"
+)
+
+// cdnTasksCmd 代表 CDN 历史任务命令组
+var cdnTasksCmd = &cobra.Command{
+	Use:   "tasks",
+	Short: "管理 CDN 历史刷新/预热任务",
+	Long:  `查询 CDN 历史刷新和预热任务。`,
+}
+
+// cdnTasksListCmd 代表列出 CDN 历史任务命令
+var cdnTasksListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "列出 CDN 历史刷新/预热任务",
+	Long: `列出指定时间范围内的 CDN 刷新和预热任务，支持按类型、状态、文件类型过滤和分页。
+
+时间参数支持相对时间（如 30m、24h、7d）和绝对时间（如 2026-10-15、2026-10-15 08:00:00、RFC3339）。
+
+示例:
+  hwcctl cdn tasks list --since 24h
+  hwcctl cdn tasks list --type refresh --status done --all
+  hwcctl cdn tasks list --since 2026-10-01 --until 2026-10-08 --page-size 100 --page 2`,
+	Args:         cobra.NoArgs,
+	RunE:         runCDNTasksList,
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// parseTimeRange 解析 --since 和 --until 指定的时间范围，--since 为空时使用 defaultSince
+func parseTimeRange(cmd *cobra.Command, now time.Time, defaultSince string) (time.Time, time.Time, error) {
+	since, _ := cmd.Flags().GetString("since")
+	until, _ := cmd.Flags().GetString("until")
+	if since == "" {
+		since = defaultSince
+	}
+	if until == "" {
+		until = "now"
+	}
+
+	start, err := utils.ParseTimeExpr(since, now)
+	if err != nil {
+		return start, now, hwErrors.NewValidationError(fmt.Sprintf("--since 参数无效: %v", err))
+	}
+	end, err := utils.ParseTimeExpr(until, now)
+	if err != nil {
+		return start, end, hwErrors.NewValidationError(fmt.Sprintf("--until 参数无效: %v", err))
+	}
+	if !start.Before(end) {
+		return start, end, hwErrors.NewValidationError("--since 必须早于 --until")
+	}
+	return start, end, nil
+}
+
+// getTaskListOptions 从命令标志中读取历史任务查询条件
+func getTaskListOptions(cmd *cobra.Command, now time.Time) (cdn.TaskListOptions, error) {
+	opts := cdn.TaskListOptions{}
+
+	var err error
+	if opts.StartTime, opts.EndTime, err = parseTimeRange(cmd, now, "7d"); err != nil {
+		return opts, err
+	}
+
+	opts.TaskType, _ = cmd.Flags().GetString("type")
+	opts.Status, _ = cmd.Flags().GetString("status")
+	opts.FileType, _ = cmd.Flags().GetString("file-type")
+	opts.TaskType = strings.ToLower(strings.TrimSpace(opts.TaskType))
+	opts.Status = strings.ToLower(strings.TrimSpace(opts.Status))
+	opts.FileType = strings.ToLower(strings.TrimSpace(opts.FileType))
+
+	if opts.TaskType != "" && !utils.StringSliceContains(cdn.TaskTypes, opts.TaskType) {
+		return opts, hwErrors.NewValidationError(fmt.Sprintf("不支持的任务类型: %s，支持的类型: %s",
+			opts.TaskType, strings.Join(cdn.TaskTypes, ", ")))
+	}
+	if opts.Status != "" && !utils.StringSliceContains(cdn.TaskStatuses, opts.Status) {
+		return opts, hwErrors.NewValidationError(fmt.Sprintf("不支持的任务状态: %s，支持的状态: %s",
+			opts.Status, strings.Join(cdn.TaskStatuses, ", ")))
+	}
+	if opts.FileType != "" && !utils.StringSliceContains(cdn.FileTypes, opts.FileType) {
+		return opts, hwErrors.NewValidationError(fmt.Sprintf("不支持的文件类型: %s，支持的类型: %s",
+			opts.FileType, strings.Join(cdn.FileTypes, ", ")))
+	}
+
+	opts.PageSize, _ = cmd.Flags().GetInt("page-size")
+	opts.PageNumber, _ = cmd.Flags().GetInt("page")
+	opts.All, _ = cmd.Flags().GetBool("all")
+	if opts.PageSize <= 0 || opts.PageSize > cdn.MaxTaskListPageSize {
+		return opts, hwErrors.NewValidationError(fmt.Sprintf("--page-size 取值范围为 1-%d", cdn.MaxTaskListPageSize))
+	}
+	if opts.PageNumber <= 0 {
+		return opts, hwErrors.NewValidationError("--page 必须大于 0")
+	}
+
+	return opts, nil
+}
+
+func runCDNTasksList(cmd *cobra.Command, args []string) error {
+	// 获取输出格式
+	outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+	formatter := output.NewFormatter(outputFormat)
+
+	opts, err := getTaskListOptions(cmd, time.Now())
+	if err != nil {
+		return err
+	}
+
+	session, err := getSession(cmd)
+	if err != nil {
+		return hwErrors.NewValidationError(fmt.Sprintf("加载配置失败: %v", err))
+	}
+	retryer := newRetryer(session)
+
+	logx.Infof("查询 CDN 历史任务，时间范围: %s ~ %s",
+		opts.StartTime.Format("2006-01-02 15:04:05"), opts.EndTime.Format("2006-01-02 15:04:05"))
+
+	ctx := context.Background()
+	result, err := retryer.DoWithResult(ctx, func() (interface{}, error) {
+		client, err := cdn.NewClient(session)
+		if err != nil {
+			return nil, hwErrors.Wrap(err, "创建 CDN 客户端失败")
+		}
+
+		taskList, err := client.ListTasks(opts)
+		if err != nil {
+			return nil, hwErrors.Wrap(err, "查询历史任务失败")
+		}
+
+		return taskList, nil
+	})
+
+	if err != nil {
+		return err
+	}
+
+	taskList := result.(*cdn.TaskList)
+	if err := formatter.Print(taskList.Tasks); err != nil {
+		return err
+	}
+
+	if formatter.IsHumanReadable() && outputFormat == "table" && len(taskList.Tasks) > 0 {
+		if opts.All {
+			fmt.Printf("\n共 %d 个任务\n", taskList.Total)
+		} else {
+			fmt.Printf("\n第 %d 页，本页 %d 个，共 %d 个任务\n", opts.PageNumber, len(taskList.Tasks), taskList.Total)
+		}
+	}
+
+	return nil
+}
+
+func init() {
+	cdnCmd.AddCommand(cdnTasksCmd)
+	cdnTasksCmd.AddCommand(cdnTasksListCmd)
+
+	cdnTasksListCmd.Flags().String("since", "7d", "起始时间，支持相对时间（如 24h、7d）或绝对时间")
+	cdnTasksListCmd.Flags().String("until", "now", "结束时间，支持相对时间（如 1h）或绝对时间")
+	cdnTasksListCmd.Flags().String("type", "", "任务类型过滤："+strings.Join(cdn.TaskTypes, "|"))
+	cdnTasksListCmd.Flags().String("status", "", "任务状态过滤："+strings.Join(cdn.TaskStatuses, "|"))
+	cdnTasksListCmd.Flags().String("file-type", "", "文件类型过滤："+strings.Join(cdn.FileTypes, "|"))
+	cdnTasksListCmd.Flags().Int("page-size", cdn.DefaultTaskListPageSize, "每页任务数量")
+	cdnTasksListCmd.Flags().Int("page", 1, "页码")
+	cdnTasksListCmd.Flags().Bool("all", false, "自动翻页获取全部任务")
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// newTasksListTestCmd 创建带有 tasks list 标志的独立测试命令，避免修改全局命令的标志状态
+func newTasksListTestCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().String("since", "7d", "")
+	cmd.Flags().String("until", "now", "")
+	cmd.Flags().String("type", "", "")
+	cmd.Flags().String("status", "", "")
+	cmd.Flags().String("file-type", "", "")
+	cmd.Flags().Int("page-size", 30, "")
+	cmd.Flags().Int("page", 1, "")
+	cmd.Flags().Bool("all", false, "")
+	return cmd
+}
+
+func TestCDNTasksListCmd(t *testing.T) {
+	if cdnTasksListCmd.RunE == nil {
+		t.Error("tasks list命令应该有RunE函数")
+	}
+	for _, name := range []string{"since", "until", "type", "status", "file-type", "page-size", "page", "all"} {
+		if cdnTasksListCmd.Flags().Lookup(name) == nil {
+			t.Errorf("tasks list命令应该有%s标志", name)
+		}
+	}
+}
+
+func TestGetTaskListOptions(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+
+	cmd := newTasksListTestCmd()
+	cmd.Flags().Set("since", "24h")
+	cmd.Flags().Set("type", "Refresh")
+	cmd.Flags().Set("all", "true")
+
+	opts, err := getTaskListOptions(cmd, now)
+	if err != nil {
+		t.Fatalf("解析查询条件失败: %v", err)
+	}
+	if !opts.StartTime.Equal(now.Add(-24*time.Hour)) || !opts.EndTime.Equal(now) {
+		t.Errorf("时间范围解析错误: %v ~ %v", opts.StartTime, opts.EndTime)
+	}
+	if opts.TaskType != "refresh" || !opts.All || opts.PageSize != 30 {
+		t.Errorf("查询条件解析错误: %+v", opts)
+	}
+
+	tests := []struct {
+		flag  string
+		value string
+	}{
+		{"since", "yesterday"},
+		{"until", "48h"}, // 结束时间早于起始时间
+		{"type", "purge"},
+		{"status", "failed"},
+		{"file-type", "dir"},
+		{"page-size", "0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.flag+"="+tt.value, func(t *testing.T) {
+			cmd := newTasksListTestCmd()
+			cmd.Flags().Set("since", "24h")
+			cmd.Flags().Set(tt.flag, tt.value)
+			if _, err := getTaskListOptions(cmd, now); err == nil {
+				t.Errorf("期望 --%s=%s 返回错误", tt.flag, tt.value)
+			}
+		})
+	}
+}
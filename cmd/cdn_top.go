@@ -0,0 +1,204 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/output"
+	"github.com/ygqygq2/hwcctl/internal/utils"
+)
+
+// topKindNames 排行类型的显示名称
+var topKindNames = map[string]string{
+	cdn.TopKindURLs:     "URL",
+	cdn.TopKindReferers: "Referer",
+	cdn.TopKindIPs:      "IP",
+}
+
+// cdnTopCmd 代表 CDN TOP 排行命令组
+var cdnTopCmd = &cobra.Command{
+	Use:   "top",
+	Short: "查询 CDN 的 TOP URL、Referer 和客户端 IP 排行",
+	Long: `按流量或请求数查询 CDN 加速域名的 TOP URL、Referer 和客户端 IP 排行。
+
+TOP URL 的时间范围按东八区零点对齐，TOP Referer 和 TOP IP 按整点对齐。`,
+}
+
+// cdnTopURLsCmd 代表 TOP URL 命令
+var cdnTopURLsCmd = &cobra.Command{
+	Use:   "urls",
+	Short: "查询 TOP URL 排行",
+	Long: `按流量或请求数查询访问量最高的 URL。
+
+使用 --emit-urls 时每行输出一个 URL，可直接交给预热命令：
+  hwcctl cdn top urls --domain www.example.com --emit-urls | hwcctl cdn preload --from-file -
+
+示例:
+  hwcctl cdn top urls --domain www.example.com --since 24h --limit 50
+  hwcctl cdn top urls --metric flux --since 7d`,
+	Args:         cobra.NoArgs,
+	RunE:         runCDNTop(cdn.TopKindURLs),
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// cdnTopReferersCmd 代表 TOP Referer 命令
+var cdnTopReferersCmd = &cobra.Command{
+	Use:   "referers",
+	Short: "查询 TOP Referer 排行",
+	Long: `按流量或请求数查询访问量最高的 Referer。
+
+示例:
+  hwcctl cdn top referers --domain www.example.com --since 24h`,
+	Args:         cobra.NoArgs,
+	RunE:         runCDNTop(cdn.TopKindReferers),
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// cdnTopIPsCmd 代表 TOP IP 命令
+var cdnTopIPsCmd = &cobra.Command{
+	Use:   "ips",
+	Short: "查询 TOP 客户端 IP 排行",
+	Long: `按流量或请求数查询访问量最高的客户端 IP。
+
+示例:
+  hwcctl cdn top ips --domain www.example.com --since 1h --metric flux`,
+	Args:         cobra.NoArgs,
+	RunE:         runCDNTop(cdn.TopKindIPs),
+	SilenceUsage: true, // 发生错误时不显示用法信息
+}
+
+// topRow TOP 排行的表格输出
+type topRow struct {
+	Rank  int    `table:"排名"`
+	Name  string `table:"名称"`
+	Value string `table:"数值"`
+}
+
+// getTopOptions 从命令标志中读取排行查询条件
+func getTopOptions(cmd *cobra.Command, kind string, now time.Time) (cdn.TopOptions, error) {
+	opts := cdn.TopOptions{Kind: kind}
+
+	var err error
+	if opts.Start, opts.End, err = parseTimeRange(cmd, now, "24h"); err != nil {
+		return opts, err
+	}
+
+	opts.Domains, _ = cmd.Flags().GetStringSlice("domain")
+	opts.Domains = utils.RemoveEmptyStrings(opts.Domains)
+
+	opts.Metric, _ = cmd.Flags().GetString("metric")
+	opts.Metric = strings.ToLower(strings.TrimSpace(opts.Metric))
+	if !utils.StringSliceContains(cdn.TopMetrics, opts.Metric) {
+		return opts, hwErrors.NewValidationError(fmt.Sprintf("排行不支持指标 %s，支持的指标: %s",
+			opts.Metric, strings.Join(cdn.TopMetrics, ", ")))
+	}
+
+	opts.Limit, _ = cmd.Flags().GetInt("limit")
+	if opts.Limit <= 0 {
+		return opts, hwErrors.NewValidationError("--limit 必须大于 0")
+	}
+
+	opts.ServiceArea, _ = cmd.Flags().GetString("service-area")
+	return opts, nil
+}
+
+// topURLs 返回排行中的 URL，没有协议的 URL 补全为 http://，并去重
+func topURLs(result *cdn.TopResult) []string {
+	urls := make([]string, 0, len(result.Items))
+	for _, item := range result.Items {
+		u := item.Name
+		if u != "" && !strings.Contains(u, "://") {
+			u = "http://" + u
+		}
+		urls = append(urls, u)
+	}
+	return cdn.NormalizeURLs(urls)
+}
+
+// printTopTable 以表格形式打印排行，数值按指标换算为易读单位
+func printTopTable(w io.Writer, formatter *output.Formatter, result *cdn.TopResult) error {
+	if len(result.Items) == 0 {
+		fmt.Fprintln(w, "没有找到数据")
+		return nil
+	}
+
+	rows := make([]topRow, 0, len(result.Items))
+	for _, item := range result.Items {
+		rows = append(rows, topRow{
+			Rank:  item.Rank,
+			Name:  item.Name,
+			Value: formatStatsValue(result.Metric, float64(item.Value)),
+		})
+	}
+	if err := formatter.Print(rows); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(w, "\nTOP %s（按%s排序）统计时间: %s - %s\n", topKindNames[result.Kind],
+		statsMetricNames[result.Metric], result.Start, result.End)
+	return nil
+}
+
+// runCDNTop 返回查询指定类型排行的命令处理函数
+func runCDNTop(kind string) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		outputFormat, _ := cmd.Root().PersistentFlags().GetString("output")
+		formatter := output.NewFormatter(outputFormat)
+
+		opts, err := getTopOptions(cmd, kind, time.Now())
+		if err != nil {
+			return err
+		}
+
+		logx.Infof("查询 CDN TOP %s 排行", topKindNames[kind])
+		result, err := runWithCDNClient(cmd, func(client *cdn.Client) (interface{}, error) {
+			top, err := client.GetTop(opts)
+			if err != nil {
+				return nil, hwErrors.Wrap(err, "查询 TOP 排行失败")
+			}
+			return top, nil
+		})
+		if err != nil {
+			return err
+		}
+
+		top := result.(*cdn.TopResult)
+		if emit, _ := cmd.Flags().GetBool("emit-urls"); emit {
+			for _, u := range topURLs(top) {
+				fmt.Fprintln(cmd.OutOrStdout(), u)
+			}
+			return nil
+		}
+
+		// csv、tsv 只输出排行明细，每项一行
+		if formatter.IsDelimited() && !formatter.HasQuery() {
+			return formatter.Print(top.Items)
+		}
+		if !formatter.IsHumanReadable() {
+			return formatter.Print(top)
+		}
+		return printTopTable(os.Stdout, formatter, top)
+	}
+}
+
+func init() {
+	cdnCmd.AddCommand(cdnTopCmd)
+	cdnTopCmd.AddCommand(cdnTopURLsCmd, cdnTopReferersCmd, cdnTopIPsCmd)
+
+	for _, c := range []*cobra.Command{cdnTopURLsCmd, cdnTopReferersCmd, cdnTopIPsCmd} {
+		c.Flags().StringSlice("domain", []string{}, "加速域名，可指定多次，默认查询全部域名")
+		c.Flags().String("metric", cdn.MetricReqNum, "排序指标："+strings.Join(cdn.TopMetrics, "|"))
+		c.Flags().Int("limit", cdn.DefaultTopLimit, "返回的最大条数")
+		c.Flags().String("since", "24h", "起始时间，支持相对时间（如 24h、7d）或绝对时间")
+		c.Flags().String("until", "now", "结束时间，支持相对时间（如 1h）或绝对时间")
+		c.Flags().String("service-area", "", "服务区域：mainland_china|outside_mainland_china")
+	}
+	cdnTopURLsCmd.Flags().Bool("emit-urls", false, "每行输出一个 URL，便于通过管道交给 cdn preload --from-file -")
+}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+)
+
+// newTopTestCmd 创建带有排行查询标志的测试命令
+func newTopTestCmd() *cobra.Command {
+	cmd := &cobra.Command{}
+	cmd.Flags().StringSlice("domain", []string{}, "")
+	cmd.Flags().String("metric", cdn.MetricReqNum, "")
+	cmd.Flags().Int("limit", cdn.DefaultTopLimit, "")
+	cmd.Flags().String("since", "24h", "")
+	cmd.Flags().String("until", "now", "")
+	cmd.Flags().String("service-area", "", "")
+	return cmd
+}
+
+func TestCDNTopCommands(t *testing.T) {
+	found := map[string]bool{}
+	for _, sub := range cdnTopCmd.Commands() {
+		found[sub.Name()] = true
+	}
+	for _, name := range []string{"urls", "referers", "ips"} {
+		if !found[name] {
+			t.Errorf("top命令应该有%s子命令", name)
+		}
+	}
+	if cdnTopURLsCmd.Flags().Lookup("emit-urls") == nil {
+		t.Error("urls命令应该有--emit-urls标志")
+	}
+}
+
+func TestGetTopOptions(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+	cmd := newTopTestCmd()
+	cmd.Flags().Set("domain", "a.example.com")
+	cmd.Flags().Set("metric", "FLUX")
+	cmd.Flags().Set("limit", "10")
+
+	opts, err := getTopOptions(cmd, cdn.TopKindIPs, now)
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if opts.Kind != cdn.TopKindIPs || opts.Metric != cdn.MetricFlux || opts.Limit != 10 || len(opts.Domains) != 1 {
+		t.Errorf("查询条件错误: %+v", opts)
+	}
+	if !opts.Start.Equal(now.Add(-24 * time.Hour)) {
+		t.Errorf("默认应查询最近 24 小时: %s", opts.Start)
+	}
+
+	for flag, value := range map[string]string{"metric": "bw", "limit": "0", "since": "yesterday"} {
+		cmd := newTopTestCmd()
+		cmd.Flags().Set(flag, value)
+		if _, err := getTopOptions(cmd, cdn.TopKindURLs, now); err == nil {
+			t.Errorf("期望 --%s=%s 校验失败", flag, value)
+		}
+	}
+}
+
+func TestTopURLs(t *testing.T) {
+	result := &cdn.TopResult{Items: []cdn.TopItem{
+		{Name: "https://CDN.example.com/a.js"},
+		{Name: "cdn.example.com/b.js"},
+		{Name: "https://cdn.example.com/a.js"},
+	}}
+	urls := topURLs(result)
+	expected := "https://cdn.example.com/a.js,http://cdn.example.com/b.js"
+	if strings.Join(urls, ",") != expected {
+		t.Errorf("期望 %s，实际为 %v", expected, urls)
+	}
+}
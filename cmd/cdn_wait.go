@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/output"
+)
+
+// 等待任务完成的默认参数
+const (
+	defaultWaitTimeout      = 10 * time.Minute
+	defaultWaitPollInterval = 5 * time.Second
+)
+
+// taskStatusGetter 查询任务状态的接口，便于测试时替换 CDN 客户端
+type taskStatusGetter interface {
+	GetTaskStatus(taskID string) (*cdn.Task, error)
+}
+
+// waitOptions 等待任务完成的参数
+type waitOptions struct {
+	Enabled      bool
+	Timeout      time.Duration
+	PollInterval time.Duration
+	// Progress 实时进度输出位置，为 nil 时不输出进度
+	Progress io.Writer
+}
+
+// addWaitFlags 为提交异步任务的命令添加等待相关标志
+func addWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().Bool("wait", false, "等待任务完成后再退出，任务失败或超时时返回非零退出码")
+	cmd.Flags().Duration("timeout", defaultWaitTimeout, "配合 --wait 使用，等待任务完成的最长时间")
+	cmd.Flags().Duration("poll-interval", defaultWaitPollInterval, "配合 --wait 使用，查询任务状态的间隔")
+}
+
+// getWaitOptions 从命令标志中读取等待参数
+func getWaitOptions(cmd *cobra.Command, outputFormat string) (waitOptions, error) {
+	opts := waitOptions{
+		Timeout:      defaultWaitTimeout,
+		PollInterval: defaultWaitPollInterval,
+	}
+
+	opts.Enabled, _ = cmd.Flags().GetBool("wait")
+	if !opts.Enabled {
+		return opts, nil
+	}
+
+	if timeout, err := cmd.Flags().GetDuration("timeout"); err == nil {
+		opts.Timeout = timeout
+	}
+	if interval, err := cmd.Flags().GetDuration("poll-interval"); err == nil {
+		opts.PollInterval = interval
+	}
+
+	if opts.Timeout <= 0 {
+		return opts, hwErrors.NewValidationError("--timeout 必须大于 0")
+	}
+	if opts.PollInterval <= 0 {
+		return opts, hwErrors.NewValidationError("--poll-interval 必须大于 0")
+	}
+
+	// 仅表格/文本模式输出实时进度，进度属于诊断信息，写入标准错误
+	if output.NewFormatter(outputFormat).IsHumanReadable() {
+		opts.Progress = os.Stderr
+	}
+
+	return opts, nil
+}
+
+// waitForTask 轮询任务状态直到任务完成、失败或超时
+// 任务失败返回 TaskFailedError，超时返回 TimeoutError，两者都会同时返回最后一次查询到的任务
+func waitForTask(ctx context.Context, client taskStatusGetter, taskID string, opts waitOptions) (*cdn.Task, error) {
+	ctx, cancel := context.WithTimeout(ctx, opts.Timeout)
+	defer cancel()
+
+	ticker := time.NewTicker(opts.PollInterval)
+	defer ticker.Stop()
+
+	var lastTask *cdn.Task
+	for {
+		task, err := client.GetTaskStatus(taskID)
+		switch {
+		case err == nil:
+			lastTask = task
+			renderTaskProgress(opts.Progress, task)
+			if task.IsFinished() {
+				finishTaskProgress(opts.Progress)
+				if task.IsFailed() {
+					return task, hwErrors.NewTaskFailedError(fmt.Sprintf("任务 %s 执行失败", taskID))
+				}
+				return task, nil
+			}
+		case isTaskNotFound(err):
+			// 新提交的任务可能暂时查询不到，继续等待
+			logx.Debugf("任务 %s 暂时查询不到，继续等待", taskID)
+		default:
+			finishTaskProgress(opts.Progress)
+			return lastTask, err
+		}
+
+		select {
+		case <-ctx.Done():
+			finishTaskProgress(opts.Progress)
+			status := "未知"
+			if lastTask != nil {
+				status = fmt.Sprintf("%s (%d%%)", lastTask.Status, lastTask.Progress)
+			}
+			return lastTask, hwErrors.NewTimeoutError(
+				fmt.Sprintf("等待任务 %s 完成超时（%s），最后状态: %s", taskID, opts.Timeout, status))
+		case <-ticker.C:
+		}
+	}
+}
+
+// isTaskNotFound 判断错误是否为任务不存在
+func isTaskNotFound(err error) bool {
+	var hwErr *hwErrors.HuaweiCloudError
+	return errors.As(err, &hwErr) && hwErr.Type == hwErrors.ErrorTypeNotFound
+}
+
+// renderTaskProgress 在同一行刷新任务进度
+func renderTaskProgress(w io.Writer, task *cdn.Task) {
+	if w == nil {
+		return
+	}
+	fmt.Fprintf(w, "\r⏳ 任务 %s: %s %3d%%", task.ID, task.Status, task.Progress)
+}
+
+// finishTaskProgress 结束进度行
+func finishTaskProgress(w io.Writer) {
+	if w == nil {
+		return
+	}
+	fmt.Fprintln(w)
+}
+
+// waitAndPrintTasks 依次等待所有任务完成并按输出格式打印最终任务状态
+// 所有任务共享同一个 --timeout 时间预算
+func waitAndPrintTasks(cmd *cobra.Command, taskIDs []string, outputFormat string, opts waitOptions) error {
+	session, err := getSession(cmd)
+	if err != nil {
+		return hwErrors.NewValidationError(fmt.Sprintf("加载配置失败: %v", err))
+	}
+
+	client, err := cdn.NewClient(session)
+	if err != nil {
+		return err
+	}
+
+	formatter := output.NewFormatter(outputFormat)
+	textMode := formatter.IsHumanReadable()
+	deadline := time.Now().Add(opts.Timeout)
+
+	tasks := make([]*cdn.Task, 0, len(taskIDs))
+	var waitErr error
+	for _, taskID := range taskIDs {
+		taskOpts := opts
+		taskOpts.Timeout = time.Until(deadline)
+		if taskOpts.Timeout <= 0 {
+			waitErr = hwErrors.NewTimeoutError(fmt.Sprintf("等待任务 %s 完成超时（%s）", taskID, opts.Timeout))
+			break
+		}
+
+		task, err := waitForTask(context.Background(), client, taskID, taskOpts)
+		if task != nil {
+			tasks = append(tasks, task)
+			if textMode {
+				printTaskDetails(task)
+			}
+		}
+		if err != nil {
+			waitErr = err
+			break
+		}
+	}
+
+	if !textMode && len(tasks) > 0 {
+		if len(taskIDs) == 1 {
+			formatter.Print(tasks[0])
+		} else {
+			formatter.Print(tasks)
+		}
+	}
+
+	if waitErr != nil {
+		return waitErr
+	}
+
+	if textMode {
+		formatter.PrintSuccess(fmt.Sprintf("%d 个任务已全部完成", len(taskIDs)))
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/ygqygq2/hwcctl/internal/cdn"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+)
+
+// fakeTaskStatusGetter 按顺序返回预设的任务状态
+type fakeTaskStatusGetter struct {
+	responses []fakeTaskResponse
+	calls     int
+}
+
+type fakeTaskResponse struct {
+	task *cdn.Task
+	err  error
+}
+
+func (f *fakeTaskStatusGetter) GetTaskStatus(taskID string) (*cdn.Task, error) {
+	idx := f.calls
+	if idx >= len(f.responses) {
+		idx = len(f.responses) - 1
+	}
+	f.calls++
+	return f.responses[idx].task, f.responses[idx].err
+}
+
+func testWaitOptions(progress *bytes.Buffer) waitOptions {
+	opts := waitOptions{
+		Enabled:      true,
+		Timeout:      time.Second,
+		PollInterval: time.Millisecond,
+	}
+	if progress != nil {
+		opts.Progress = progress
+	}
+	return opts
+}
+
+func TestWaitForTaskDone(t *testing.T) {
+	getter := &fakeTaskStatusGetter{responses: []fakeTaskResponse{
+		{err: hwErrors.NewNotFoundError("任务 t-1")},
+		{task: &cdn.Task{ID: "t-1", Status: cdn.TaskStatusProcessing, Progress: 50}},
+		{task: &cdn.Task{ID: "t-1", Status: cdn.TaskStatusDone, Progress: 100}},
+	}}
+
+	var progress bytes.Buffer
+	task, err := waitForTask(context.Background(), getter, "t-1", testWaitOptions(&progress))
+	if err != nil {
+		t.Fatalf("期望任务成功完成，实际出错: %v", err)
+	}
+	if task.Status != cdn.TaskStatusDone {
+		t.Errorf("期望最终状态为已完成，实际为 %s", task.Status)
+	}
+	if getter.calls != 3 {
+		t.Errorf("期望查询 3 次，实际 %d 次", getter.calls)
+	}
+	if !strings.Contains(progress.String(), " 50%") || !strings.Contains(progress.String(), "100%") {
+		t.Errorf("进度输出应包含中间进度，实际为 %q", progress.String())
+	}
+}
+
+func TestWaitForTaskFailed(t *testing.T) {
+	getter := &fakeTaskStatusGetter{responses: []fakeTaskResponse{
+		{task: &cdn.Task{ID: "t-2", Status: cdn.TaskStatusFailed, Progress: 100}},
+	}}
+
+	task, err := waitForTask(context.Background(), getter, "t-2", testWaitOptions(nil))
+	if task == nil || task.ID != "t-2" {
+		t.Fatalf("任务失败时也应返回最终任务，实际为 %+v", task)
+	}
+	if hwErrors.ExitCode(err) != hwErrors.ExitCodeTaskFailed {
+		t.Errorf("期望任务失败退出码 %d，实际为 %d (%v)", hwErrors.ExitCodeTaskFailed, hwErrors.ExitCode(err), err)
+	}
+}
+
+func TestWaitForTaskTimeout(t *testing.T) {
+	getter := &fakeTaskStatusGetter{responses: []fakeTaskResponse{
+		{task: &cdn.Task{ID: "t-3", Status: cdn.TaskStatusProcessing, Progress: 10}},
+	}}
+
+	opts := testWaitOptions(nil)
+	opts.Timeout = 20 * time.Millisecond
+	opts.PollInterval = 5 * time.Millisecond
+
+	task, err := waitForTask(context.Background(), getter, "t-3", opts)
+	if task == nil || task.Progress != 10 {
+		t.Errorf("超时时应返回最后一次查询到的任务，实际为 %+v", task)
+	}
+	if hwErrors.ExitCode(err) != hwErrors.ExitCodeTimeout {
+		t.Errorf("期望超时退出码 %d，实际为 %d (%v)", hwErrors.ExitCodeTimeout, hwErrors.ExitCode(err), err)
+	}
+}
+
+func TestWaitForTaskQueryError(t *testing.T) {
+	getter := &fakeTaskStatusGetter{responses: []fakeTaskResponse{
+		{err: hwErrors.NewAuthError("认证失败")},
+	}}
+
+	_, err := waitForTask(context.Background(), getter, "t-4", testWaitOptions(nil))
+	if err == nil || !strings.Contains(err.Error(), "认证失败") {
+		t.Errorf("期望返回查询错误，实际为 %v", err)
+	}
+	if getter.calls != 1 {
+		t.Errorf("非 NotFound 错误不应继续轮询，实际查询 %d 次", getter.calls)
+	}
+}
+
+func TestIsTaskNotFound(t *testing.T) {
+	notFound := hwErrors.NewNotFoundError("任务 t-1")
+	if !isTaskNotFound(notFound) || !isTaskNotFound(fmt.Errorf("查询任务状态失败: %w", notFound)) {
+		t.Error("任务不存在的错误（包括被包装的错误）应识别为 NotFound")
+	}
+	if isTaskNotFound(hwErrors.NewServerError("内部错误")) || isTaskNotFound(nil) {
+		t.Error("其他错误不应识别为 NotFound")
+	}
+}
+
+func TestGetWaitOptions(t *testing.T) {
+	cmd := &cobra.Command{}
+	addWaitFlags(cmd)
+
+	opts, err := getWaitOptions(cmd, "table")
+	if err != nil || opts.Enabled {
+		t.Fatalf("未指定 --wait 时不应启用等待: %+v, %v", opts, err)
+	}
+
+	cmd.Flags().Set("wait", "true")
+	cmd.Flags().Set("timeout", "30s")
+	cmd.Flags().Set("poll-interval", "2s")
+	opts, err = getWaitOptions(cmd, "json")
+	if err != nil {
+		t.Fatalf("读取等待参数失败: %v", err)
+	}
+	if !opts.Enabled || opts.Timeout != 30*time.Second || opts.PollInterval != 2*time.Second {
+		t.Errorf("等待参数解析错误: %+v", opts)
+	}
+	if opts.Progress != nil {
+		t.Error("json 模式不应输出实时进度")
+	}
+
+	cmd.Flags().Set("poll-interval", "0s")
+	if _, err := getWaitOptions(cmd, "table"); err == nil {
+		t.Error("期望 --poll-interval 为 0 时返回错误")
+	}
+}
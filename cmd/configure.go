@@ -13,76 +13,84 @@ import (
 	"gopkg.in/yaml.v3"
 )
 
-// Config 配置文件结构
-type Config struct {
-	Default Profile `yaml:"default"`
-}
+// Config 配置文件结构，与 auth 包读取的结构保持一致，避免保存时丢失字段
+type Config = auth.ConfigFile
 
 // Profile 配置文件中的 profile
-type Profile struct {
-	AccessKeyID     string `yaml:"access_key_id"`
-	SecretAccessKey string `yaml:"secret_access_key"`
-	Region          string `yaml:"region"`
-	Output          string `yaml:"output"`
-}
+type Profile = auth.Profile
 
 // configureCmd 代表配置命令
 var configureCmd = &cobra.Command{
 	Use:   "configure",
 	Short: "配置华为云凭证和设置",
 	Long: `交互式配置华为云访问凭证、默认区域和输出格式。
-类似于 AWS CLI 的 configure 命令，将配置保存到 ~/.hwcctl/config 文件中。`,
+类似于 AWS CLI 的 configure 命令，将配置保存到 ~/.hwcctl/config 文件中。
+
+使用 --profile NAME 可以创建或编辑命名 profile，例如:
+  hwcctl configure --profile staging`,
 	RunE: runConfigure,
 }
 
 func runConfigure(cmd *cobra.Command, args []string) error {
 	reader := bufio.NewReader(os.Stdin)
 
-	// 获取当前配置
+	// 获取当前配置及选定的 profile
 	config := loadConfig()
+	profileName := auth.ResolveProfileName()
+	profile, exists := config.GetProfile(profileName)
+	if !exists {
+		profile = Profile{
+			Region: "cn-north-1",
+			Output: "table",
+		}
+	}
 
 	fmt.Println("华为云 CLI 配置")
+	if profileName != auth.DefaultProfileName {
+		fmt.Printf("Profile: %s\n", profileName)
+	}
 	fmt.Println("请输入你的华为云访问凭证信息:")
 
 	// 配置 Access Key ID
-	fmt.Printf("Huawei Cloud Access Key ID [%s]: ", maskString(config.Default.AccessKeyID))
+	fmt.Printf("Huawei Cloud Access Key ID [%s]: ", maskString(profile.AccessKeyID))
 	accessKey, _ := reader.ReadString('\n')
 	accessKey = strings.TrimSpace(accessKey)
 	if accessKey != "" {
-		config.Default.AccessKeyID = accessKey
+		profile.AccessKeyID = accessKey
 	}
 
 	// 配置 Secret Access Key
-	fmt.Printf("Huawei Cloud Secret Access Key [%s]: ", maskString(config.Default.SecretAccessKey))
+	fmt.Printf("Huawei Cloud Secret Access Key [%s]: ", maskString(profile.SecretAccessKey))
 	secretKey, _ := reader.ReadString('\n')
 	secretKey = strings.TrimSpace(secretKey)
 	if secretKey != "" {
-		config.Default.SecretAccessKey = secretKey
+		profile.SecretAccessKey = secretKey
 	}
 
 	// 配置默认区域
-	fmt.Printf("Default region name [%s]: ", config.Default.Region)
+	fmt.Printf("Default region name [%s]: ", profile.Region)
 	region, _ := reader.ReadString('\n')
 	region = strings.TrimSpace(region)
 	if region != "" {
-		config.Default.Region = region
+		profile.Region = region
 	}
 
 	// 配置输出格式
-	fmt.Printf("Default output format [%s]: ", config.Default.Output)
+	fmt.Printf("Default output format [%s]: ", profile.Output)
 	output, _ := reader.ReadString('\n')
 	output = strings.TrimSpace(output)
 	if output != "" {
-		config.Default.Output = output
+		profile.Output = output
 	}
 
 	// 保存配置
+	config.SetProfile(profileName, profile)
 	if err := saveConfig(config); err != nil {
 		return fmt.Errorf("保存配置失败: %v", err)
 	}
 
 	fmt.Println("✅ 配置已保存")
-	logx.Infof("配置文件已保存到: %s", auth.ResolveConfigPath())
+	logx.Infof("配置文件已保存到: %s (profile: %s)", auth.ResolveConfigPath(), profileName)
 
 	return nil
 }
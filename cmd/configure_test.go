@@ -164,6 +164,40 @@ func TestSaveConfig(t *testing.T) {
 	}
 }
 
+func TestSaveConfigNamedProfile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config")
+	auth.SetConfigPath(configPath)
+	defer auth.SetConfigPath("")
+
+	config := Config{
+		Default: Profile{
+			AccessKeyID: "default-key",
+			Region:      "cn-north-1",
+			DomainID:    "default-domain",
+		},
+	}
+	config.SetProfile("staging", Profile{
+		AccessKeyID: "staging-key",
+		Region:      "cn-east-3",
+	})
+
+	if err := saveConfig(config); err != nil {
+		t.Fatalf("保存配置失败: %v", err)
+	}
+
+	loaded := loadConfig()
+	if loaded.Default.DomainID != "default-domain" {
+		t.Errorf("保存命名 profile 后默认 profile 的 DomainID 丢失")
+	}
+	staging, ok := loaded.GetProfile("staging")
+	if !ok {
+		t.Fatal("重新加载后找不到 staging profile")
+	}
+	if staging.AccessKeyID != "staging-key" || staging.Region != "cn-east-3" {
+		t.Errorf("staging profile 内容不匹配: %+v", staging)
+	}
+}
+
 func TestRunConfigure(t *testing.T) {
 	// 测试configure命令的运行函数
 	// 由于runConfigure需要交互输入，这里只测试它不会panic
@@ -1,12 +1,16 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"os"
 
 	"github.com/spf13/cobra"
 	"github.com/ygqygq2/hwcctl/internal/auth"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
 	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/output"
 )
 
 var (
@@ -37,11 +41,22 @@ var rootCmd = &cobra.Command{
 
 类似于 AWS CLI，但专门针对华为云服务设计。`,
 	Version: version,
-	PersistentPreRun: func(cmd *cobra.Command, args []string) {
-		// 处理调试标志
-		debug, _ := cmd.Flags().GetBool("debug")
-		if debug {
-			logx.SetLevel("debug")
+	// 错误由 Execute 统一输出到标准错误
+	SilenceErrors: true,
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		if err := setupLogging(cmd); err != nil {
+			return err
+		}
+
+		query, _ := cmd.Flags().GetString("query")
+		if err := output.SetQuery(query); err != nil {
+			return err
+		}
+		noHeaders, _ := cmd.Flags().GetBool("no-headers")
+		output.SetNoHeaders(noHeaders)
+		headerStyle, _ := cmd.Flags().GetString("header-style")
+		if err := output.SetHeaderStyle(headerStyle); err != nil {
+			return err
 		}
 
 		configPath, _ := cmd.Flags().GetString("config")
@@ -49,12 +64,132 @@ var rootCmd = &cobra.Command{
 			configPath = os.Getenv("HWCCTL_CONFIG")
 		}
 		auth.SetConfigPath(configPath)
+
+		profile, _ := cmd.Flags().GetString("profile")
+		if profile == "" {
+			profile = os.Getenv("HWCCTL_PROFILE")
+		}
+		auth.SetProfile(profile)
+
+		initSession(cmd)
+		return setupLogFile(cmd)
 	},
 }
 
+// setupLogging 根据 --verbose、--debug、--debug-http 和 --log-format 配置日志
+// 默认只输出警告和错误，--verbose 输出执行过程，--debug 输出调试信息，--debug-http 额外记录 HTTP 请求详情
+func setupLogging(cmd *cobra.Command) error {
+	verbose, _ := cmd.Flags().GetBool("verbose")
+	debug, _ := cmd.Flags().GetBool("debug")
+	debugHTTP, _ := cmd.Flags().GetBool("debug-http")
+	logx.SetHTTPDebug(debugHTTP)
+	switch {
+	case debug || debugHTTP:
+		logx.SetLevel("debug")
+	case verbose:
+		logx.SetLevel("info")
+	default:
+		logx.SetLevel("warn")
+	}
+
+	logFormat, _ := cmd.Flags().GetString("log-format")
+	if logFormat == "" {
+		logFormat = os.Getenv("HWCCTL_LOG_FORMAT")
+	}
+	if err := logx.SetFormat(logFormat); err != nil {
+		return hwErrors.NewValidationError(err.Error())
+	}
+	logx.SetCommand(cmd.CommandPath())
+	return nil
+}
+
+// setupLogFile 按 --log-file > 环境变量 HWCCTL_LOG_FILE > profile 中 log_file 的优先级配置日志文件
+// 日志文件记录完整的调试日志并按大小轮转，终端输出级别不受影响
+func setupLogFile(cmd *cobra.Command) error {
+	opts := logx.FileOptions{}
+	if currentSession != nil {
+		config := currentSession.Config()
+		opts.Path = config.LogFile
+		opts.MaxSize = int64(config.LogMaxSize) * 1024 * 1024
+		opts.MaxBackups = config.LogMaxBackups
+	}
+	if envPath := os.Getenv("HWCCTL_LOG_FILE"); envPath != "" {
+		opts.Path = envPath
+	}
+	if flagPath, _ := cmd.Flags().GetString("log-file"); flagPath != "" {
+		opts.Path = flagPath
+	}
+	if opts.Path == "" {
+		return nil
+	}
+
+	if err := logx.SetFile(opts); err != nil {
+		return hwErrors.NewValidationError(fmt.Sprintf("配置日志文件失败: %v", err))
+	}
+	logx.Debug("开始执行命令", "version", version, "profile", auth.ResolveProfileName())
+	return nil
+}
+
+// 当前命令执行使用的会话，在 PersistentPreRun 中解析一次后传递给各服务客户端
+var (
+	currentSession *auth.Session
+	sessionErr     error
+)
+
+// initSession 按 命令行参数 > 环境变量 > profile 的优先级解析会话
+// 解析失败时只记录错误，由需要凭证的命令在 getSession 时返回，避免影响 configure 等命令
+func initSession(cmd *cobra.Command) {
+	accessKey, _ := cmd.Flags().GetString("access-key-id")
+	secretKey, _ := cmd.Flags().GetString("secret-access-key")
+	region, _ := cmd.Flags().GetString("region")
+	domainID, _ := cmd.Flags().GetString("domain-id")
+	endpointURL, _ := cmd.Flags().GetString("endpoint-url")
+
+	currentSession, sessionErr = auth.NewSession(auth.SessionOptions{
+		AccessKeyID:     accessKey,
+		SecretAccessKey: secretKey,
+		Region:          region,
+		DomainID:        domainID,
+		EndpointURL:     endpointURL,
+	})
+}
+
+// getSession 返回当前命令的会话，未经过 PersistentPreRun 时（例如直接调用 RunE）按当前标志解析
+func getSession(cmd *cobra.Command) (*auth.Session, error) {
+	if currentSession == nil && sessionErr == nil {
+		initSession(cmd)
+	}
+	return currentSession, sessionErr
+}
+
 // Execute 添加所有子命令到根命令并适当设置标志
+// 执行失败时将错误输出到标准错误，调用方只需根据错误决定退出码
 func Execute() error {
-	return rootCmd.Execute()
+	err := rootCmd.Execute()
+	defer logx.Close()
+	if err != nil {
+		logx.Debug("命令执行失败", logx.KeyError, err)
+		outputFormat, _ := rootCmd.PersistentFlags().GetString("output")
+		reportError(os.Stderr, outputFormat, err)
+	}
+	return err
+}
+
+// reportError 输出命令执行错误
+// json、yaml 格式下输出结构化错误文档，其他格式输出错误信息和处理建议
+func reportError(w io.Writer, outputFormat string, err error) {
+	formatter := output.NewFormatter(outputFormat)
+	if formatter.IsStructured() {
+		if encodeErr := formatter.PrintErrorDocument(w, err); encodeErr == nil {
+			return
+		}
+	}
+
+	fmt.Fprintf(w, "执行命令失败: %v\n", err)
+	var hwErr *hwErrors.HuaweiCloudError
+	if errors.As(err, &hwErr) && hwErr.Hint != "" {
+		fmt.Fprintf(w, "提示: %s\n", hwErr.Hint)
+	}
 }
 
 func init() {
@@ -69,12 +204,18 @@ Git 提交: ` + gitCommit + `
 	rootCmd.PersistentFlags().String("access-key-id", "", "Access Key ID (也可使用环境变量 HUAWEICLOUD_ACCESS_KEY)")
 	rootCmd.PersistentFlags().String("secret-access-key", "", "Secret Access Key (也可使用环境变量 HUAWEICLOUD_SECRET_KEY)")
 	rootCmd.PersistentFlags().String("domain-id", "", "Domain ID (也可使用环境变量 HUAWEICLOUD_DOMAIN_ID)")
-	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "详细输出")
-	rootCmd.PersistentFlags().BoolP("debug", "d", false, "调试模式")
-	rootCmd.PersistentFlags().String("output", "table", "输出格式 (table|json|yaml)")
-	rootCmd.PersistentFlags().String("profile", "", "使用指定的配置文件 profile")
+	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "详细输出，在标准错误中显示执行过程日志")
+	rootCmd.PersistentFlags().BoolP("debug", "d", false, "调试模式，在标准错误中显示调试日志")
+	rootCmd.PersistentFlags().Bool("debug-http", false, "记录每个 HTTP 请求的方法、URL、请求头、内容和耗时，认证信息和私钥会脱敏 (隐含 --debug)")
+	rootCmd.PersistentFlags().String("log-format", "", "日志格式 (text|json)，json 格式每行输出一个 JSON 对象 (也可使用环境变量 HWCCTL_LOG_FORMAT)")
+	rootCmd.PersistentFlags().String("log-file", "", "将完整的调试日志写入文件，按大小轮转 (也可使用环境变量 HWCCTL_LOG_FILE 或配置项 log_file)")
+	rootCmd.PersistentFlags().StringP("output", "o", "table", "输出格式 (table|json|yaml|text|csv|tsv)")
+	rootCmd.PersistentFlags().String("query", "", "使用 JMESPath 表达式筛选输出结果，字段名与 JSON 输出一致，例如 \"[?status=='失败'].id\"")
+	rootCmd.PersistentFlags().Bool("no-headers", false, "csv、tsv 格式不输出表头行")
+	rootCmd.PersistentFlags().String("header-style", output.HeaderStyleTable, "csv、tsv 表头使用的字段名 (table|json)，table 与表格输出的列名一致，json 与 JSON 字段名一致")
+	rootCmd.PersistentFlags().String("profile", "", "使用指定的配置文件 profile (也可使用环境变量 HWCCTL_PROFILE)")
 	rootCmd.PersistentFlags().String("config", "", "指定配置文件路径 (默认 ~/.hwcctl/config)")
-	rootCmd.PersistentFlags().String("endpoint-url", "", "覆盖默认的服务端点 URL")
+	rootCmd.PersistentFlags().String("endpoint-url", "", "覆盖服务端点 URL，单个 URL 应用于所有服务，或使用 cdn=URL,iam=URL 分别指定 (也可使用环境变量 HWCCTL_ENDPOINT_CDN 等)")
 
 	// 设置使用帮助
 	rootCmd.SetHelpCommand(&cobra.Command{
@@ -1,7 +1,20 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/ygqygq2/hwcctl/internal/auth"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
 )
 
 func TestRootCmd(t *testing.T) {
@@ -94,3 +107,231 @@ func TestVersionVariables(t *testing.T) {
 	// 恢复原始值
 	SetVersionInfo(originalVersion, originalBuildTime, originalGitCommit)
 }
+
+func TestGetSessionUsesGlobalFlags(t *testing.T) {
+	auth.SetConfigPath(filepath.Join(t.TempDir(), "nonexistent"))
+	defer auth.SetConfigPath("")
+	t.Setenv("HUAWEICLOUD_ACCESS_KEY", "env-ak")
+	t.Setenv("HUAWEICLOUD_SECRET_KEY", "env-sk")
+	t.Setenv("HUAWEICLOUD_REGION", "cn-east-3")
+
+	prevSession, prevErr := currentSession, sessionErr
+	defer func() { currentSession, sessionErr = prevSession, prevErr }()
+	currentSession, sessionErr = nil, nil
+
+	cmd := &cobra.Command{}
+	cmd.Flags().String("access-key-id", "", "")
+	cmd.Flags().String("secret-access-key", "", "")
+	cmd.Flags().String("region", "", "")
+	cmd.Flags().String("domain-id", "", "")
+	cmd.Flags().Set("access-key-id", "flag-ak")
+	cmd.Flags().Set("domain-id", "flag-domain")
+
+	session, err := getSession(cmd)
+	if err != nil {
+		t.Fatalf("解析会话失败: %v", err)
+	}
+
+	creds, err := session.Credentials()
+	if err != nil {
+		t.Fatalf("获取凭证失败: %v", err)
+	}
+	if creds.AccessKeyID != "flag-ak" {
+		t.Errorf("期望命令行参数优先，AccessKey 实际为 %s", creds.AccessKeyID)
+	}
+	if creds.SecretAccessKey != "env-sk" {
+		t.Errorf("期望未指定的参数使用环境变量，SecretKey 实际为 %s", creds.SecretAccessKey)
+	}
+	if creds.Region != "cn-east-3" || creds.DomainID != "flag-domain" {
+		t.Errorf("区域或 DomainID 解析错误: %+v", creds)
+	}
+
+	// 再次获取时复用同一个会话
+	again, _ := getSession(cmd)
+	if again != session {
+		t.Error("期望同一次执行中复用已解析的会话")
+	}
+}
+
+func TestReportError(t *testing.T) {
+	authErr := hwErrors.NewAuthError("认证失败")
+	authErr.Hint = "请检查 AK/SK"
+	err := hwErrors.Wrap(authErr, "查询加速域名失败")
+
+	var buf bytes.Buffer
+	reportError(&buf, "json", err)
+	var doc map[string]interface{}
+	if e := json.Unmarshal(buf.Bytes(), &doc); e != nil {
+		t.Fatalf("json 格式下应输出结构化错误: %v, 输出: %s", e, buf.String())
+	}
+	if doc["type"] != string(hwErrors.ErrorTypeAuth) || doc["message"] != "查询加速域名失败: 认证失败" {
+		t.Errorf("错误文档字段错误: %v", doc)
+	}
+
+	buf.Reset()
+	reportError(&buf, "table", err)
+	if !strings.HasPrefix(buf.String(), "执行命令失败: ") || !strings.Contains(buf.String(), "提示: 请检查 AK/SK") {
+		t.Errorf("table 格式下应输出错误信息和处理建议: %q", buf.String())
+	}
+}
+
+// executeWithFailingEndpoint 以指定参数执行根命令，所有服务端点指向返回认证失败的模拟服务
+// 返回标准输出和标准错误的内容
+func executeWithFailingEndpoint(t *testing.T, args ...string) (string, string, error) {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-401")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error_code": "APIGW.0301", "error_msg": "Incorrect IAM authentication information"}`))
+	}))
+	t.Cleanup(server.Close)
+	t.Setenv("HWCCTL_CONFIG", filepath.Join(t.TempDir(), "config"))
+
+	dir := t.TempDir()
+	stdout, err := os.Create(filepath.Join(dir, "stdout"))
+	if err != nil {
+		t.Fatalf("创建文件失败: %v", err)
+	}
+	stderr, err := os.Create(filepath.Join(dir, "stderr"))
+	if err != nil {
+		t.Fatalf("创建文件失败: %v", err)
+	}
+	defer stdout.Close()
+	defer stderr.Close()
+
+	prevStdout, prevStderr := os.Stdout, os.Stderr
+	prevSession, prevErr := currentSession, sessionErr
+	os.Stdout, os.Stderr = stdout, stderr
+	logx.SetOutput(stderr)
+	defer func() {
+		os.Stdout, os.Stderr = prevStdout, prevStderr
+		logx.SetOutput(prevStderr)
+		currentSession, sessionErr = prevSession, prevErr
+		rootCmd.SetArgs(nil)
+		rootCmd.PersistentFlags().VisitAll(func(f *pflag.Flag) {
+			f.Value.Set(f.DefValue)
+			f.Changed = false
+		})
+	}()
+
+	rootCmd.SetArgs(append([]string{"--endpoint-url", server.URL, "--access-key-id", "test-ak",
+		"--secret-access-key", "test-sk", "--region", "cn-north-1", "--domain-id", "test-domain-id"}, args...))
+	execErr := Execute()
+
+	out, _ := os.ReadFile(stdout.Name())
+	errOut, _ := os.ReadFile(stderr.Name())
+	return string(out), string(errOut), execErr
+}
+
+func TestExecuteJSONErrorDocument(t *testing.T) {
+	stdout, stderr, err := executeWithFailingEndpoint(t, "--output", "json",
+		"cdn", "refresh", "--urls", "https://cdn.example.com/a.js")
+	if hwErrors.ExitCode(err) != hwErrors.ExitCodeAuth {
+		t.Fatalf("期望认证失败，实际为 %v", err)
+	}
+	if stdout != "" {
+		t.Errorf("失败时标准输出应为空，实际输出: %q", stdout)
+	}
+
+	// 标准错误只包含一个可解析的错误文档
+	decoder := json.NewDecoder(strings.NewReader(stderr))
+	var doc map[string]interface{}
+	if e := decoder.Decode(&doc); e != nil {
+		t.Fatalf("标准错误应为 JSON 错误文档: %v, 输出: %s", e, stderr)
+	}
+	if doc["code"] != "APIGW.0301" || doc["request_id"] != "req-401" {
+		t.Errorf("错误文档字段错误: %v", doc)
+	}
+	if decoder.More() {
+		t.Errorf("标准错误中除错误文档外不应有其他内容: %s", stderr)
+	}
+}
+
+func TestExecuteTableErrorReportedOnce(t *testing.T) {
+	stdout, stderr, err := executeWithFailingEndpoint(t, "cdn", "task", "abc")
+	if hwErrors.ExitCode(err) != hwErrors.ExitCodeAuth {
+		t.Fatalf("期望认证失败，实际为 %v", err)
+	}
+	if stdout != "" {
+		t.Errorf("失败时标准输出应为空，实际输出: %q", stdout)
+	}
+	if strings.Count(stderr, "执行命令失败: ") != 1 || strings.Contains(stderr, "❌") || strings.Contains(stderr, "[ERROR]") {
+		t.Errorf("错误应只由 Execute 输出一次，实际输出: %q", stderr)
+	}
+	if !strings.Contains(stderr, "提示: ") {
+		t.Errorf("应输出处理建议，实际输出: %q", stderr)
+	}
+}
+
+func TestSetupLogging(t *testing.T) {
+	t.Cleanup(func() {
+		logx.SetFormat(logx.FormatText)
+		logx.SetCommand("")
+		logx.SetLevel("warn")
+	})
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "refresh"}
+		cmd.Flags().Bool("verbose", false, "")
+		cmd.Flags().Bool("debug", false, "")
+		cmd.Flags().String("log-format", "", "")
+		return cmd
+	}
+
+	t.Setenv("HWCCTL_LOG_FORMAT", "xml")
+	if err := setupLogging(newCmd()); hwErrors.ExitCode(err) != hwErrors.ExitCodeValidation {
+		t.Errorf("环境变量指定不支持的日志格式时应返回参数错误，实际为 %v", err)
+	}
+
+	cmd := newCmd()
+	cmd.Flags().Set("log-format", "json")
+	if err := setupLogging(cmd); err != nil {
+		t.Errorf("命令行参数应优先于环境变量: %v", err)
+	}
+}
+
+func TestSetupLogFile(t *testing.T) {
+	dir := t.TempDir()
+	prevSession, prevErr := currentSession, sessionErr
+	t.Cleanup(func() {
+		currentSession, sessionErr = prevSession, prevErr
+		logx.Close()
+	})
+	currentSession = auth.NewSessionFromConfig(&auth.Config{LogFile: filepath.Join(dir, "profile.log")})
+
+	newCmd := func() *cobra.Command {
+		cmd := &cobra.Command{Use: "refresh"}
+		cmd.Flags().String("log-file", "", "")
+		return cmd
+	}
+
+	cases := []struct {
+		name string
+		env  string
+		flag string
+		want string
+	}{
+		{name: "profile", want: "profile.log"},
+		{name: "env", env: filepath.Join(dir, "env.log"), want: "env.log"},
+		{name: "flag", env: filepath.Join(dir, "env.log"), flag: filepath.Join(dir, "flag.log"), want: "flag.log"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Setenv("HWCCTL_LOG_FILE", tc.env)
+			cmd := newCmd()
+			if tc.flag != "" {
+				cmd.Flags().Set("log-file", tc.flag)
+			}
+			if err := setupLogFile(cmd); err != nil {
+				t.Fatalf("配置日志文件失败: %v", err)
+			}
+			logx.Close()
+
+			content, err := os.ReadFile(filepath.Join(dir, tc.want))
+			if err != nil || !strings.Contains(string(content), "开始执行命令") {
+				t.Errorf("日志应写入 %s: %q (%v)", tc.want, content, err)
+			}
+		})
+	}
+}
@@ -16,6 +16,8 @@ import (
 	"strings"
 	"time"
 
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
 	"gopkg.in/yaml.v3"
 )
 
@@ -29,6 +31,12 @@ type Config struct {
 	EnterpriseProjectID string `yaml:"enterprise_project_id"` // 企业项目ID，默认为 "0"
 	MaxRetries          int    `yaml:"max_retries"`           // 最大重试次数，默认 0（不重试）
 	EnableRetry         bool   `yaml:"enable_retry"`          // 是否启用重试，默认 false
+	Profile             string `yaml:"-"`                     // 加载时使用的 profile 名称
+	LogFile             string `yaml:"log_file"`              // 调试日志文件路径，为空时不写入文件
+	LogMaxSize          int    `yaml:"log_max_size"`          // 单个日志文件的最大大小（MB）
+	LogMaxBackups       int    `yaml:"log_max_backups"`       // 保留的历史日志文件数量
+	// 服务端点覆盖，键为服务名称（cdn、iam），值为端点 URL
+	Endpoints map[string]string `yaml:"endpoints"`
 }
 
 // Credentials 华为云认证凭证
@@ -50,13 +58,55 @@ type Profile struct {
 	ProjectID           string `yaml:"project_id"`            // 项目ID
 	EnterpriseProjectID string `yaml:"enterprise_project_id"` // 企业项目ID，默认为 "0"
 	Output              string `yaml:"output"`
-	MaxRetries          int    `yaml:"max_retries"`  // 最大重试次数，默认 0
-	EnableRetry         bool   `yaml:"enable_retry"` // 是否启用重试，默认 false
+	MaxRetries          int    `yaml:"max_retries"`               // 最大重试次数，默认 0
+	EnableRetry         bool   `yaml:"enable_retry"`              // 是否启用重试，默认 false
+	LogFile             string `yaml:"log_file,omitempty"`        // 调试日志文件路径
+	LogMaxSize          int    `yaml:"log_max_size,omitempty"`    // 单个日志文件的最大大小（MB），默认 10
+	LogMaxBackups       int    `yaml:"log_max_backups,omitempty"` // 保留的历史日志文件数量，默认 5
+	// 服务端点覆盖，例如 endpoints: {cdn: "https://cdn.example.com"}
+	Endpoints map[string]string `yaml:"endpoints,omitempty"`
 }
 
+// DefaultProfileName 默认 profile 名称
+const DefaultProfileName = "default"
+
 // ConfigFile 配置文件结构
 type ConfigFile struct {
-	Default Profile `yaml:"default"`
+	Default  Profile            `yaml:"default"`
+	Profiles map[string]Profile `yaml:"profiles,omitempty"` // 命名 profile，通过 --profile 或 HWCCTL_PROFILE 选择
+}
+
+// GetProfile 根据名称获取 profile，空名称或 "default" 返回默认 profile
+func (cf *ConfigFile) GetProfile(name string) (Profile, bool) {
+	if name == "" || name == DefaultProfileName {
+		return cf.Default, true
+	}
+	profile, ok := cf.Profiles[name]
+	return profile, ok
+}
+
+// SetProfile 根据名称写入 profile，空名称或 "default" 写入默认 profile
+func (cf *ConfigFile) SetProfile(name string, profile Profile) {
+	if name == "" || name == DefaultProfileName {
+		cf.Default = profile
+		return
+	}
+	if cf.Profiles == nil {
+		cf.Profiles = make(map[string]Profile)
+	}
+	cf.Profiles[name] = profile
+}
+
+// ProfileNames 返回配置文件中所有 profile 名称（default 在前，其余按字母排序）
+func (cf *ConfigFile) ProfileNames() []string {
+	names := make([]string, 0, len(cf.Profiles))
+	for name := range cf.Profiles {
+		if name != DefaultProfileName {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return append([]string{DefaultProfileName}, names...)
 }
 
 // Project 项目信息
@@ -83,6 +133,25 @@ type TokenResponse struct {
 // configPathOverride 用于覆盖默认配置文件路径
 var configPathOverride string
 
+// profileOverride 用于覆盖默认使用的 profile 名称
+var profileOverride string
+
+// SetProfile 设置使用的 profile 名称，传入空字符串将恢复默认解析逻辑
+func SetProfile(name string) {
+	profileOverride = strings.TrimSpace(name)
+}
+
+// ResolveProfileName 返回当前解析到的 profile 名称，优先级：--profile > HWCCTL_PROFILE > default
+func ResolveProfileName() string {
+	if profileOverride != "" {
+		return profileOverride
+	}
+	if envProfile := strings.TrimSpace(os.Getenv("HWCCTL_PROFILE")); envProfile != "" {
+		return envProfile
+	}
+	return DefaultProfileName
+}
+
 // SetConfigPath 设置配置文件路径覆盖，传入空字符串将恢复默认解析逻辑
 func SetConfigPath(path string) {
 	configPathOverride = strings.TrimSpace(path)
@@ -104,19 +173,33 @@ func NewConfig(accessKey, secretKey, region string) *Config {
 
 // LoadConfig 加载配置信息，优先级：命令行参数 > 环境变量 > 配置文件
 func LoadConfig(accessKeyFlag, secretKeyFlag, regionFlag, domainIDFlag string) (*Config, error) {
-	config := &Config{}
+	profileName := ResolveProfileName()
+	config := &Config{Profile: profileName, Endpoints: make(map[string]string)}
 
-	// 1. 尝试从配置文件读取
+	// 1. 尝试从配置文件读取选定的 profile
 	configFile := loadConfigFile()
 	if configFile != nil {
-		config.AccessKey = configFile.Default.AccessKeyID
-		config.SecretKey = configFile.Default.SecretAccessKey
-		config.Region = configFile.Default.Region
-		config.DomainID = configFile.Default.DomainID
-		config.ProjectID = configFile.Default.ProjectID
-		config.EnterpriseProjectID = configFile.Default.EnterpriseProjectID
-		config.MaxRetries = configFile.Default.MaxRetries
-		config.EnableRetry = configFile.Default.EnableRetry
+		profile, ok := configFile.GetProfile(profileName)
+		if !ok {
+			return nil, fmt.Errorf("配置文件 %s 中不存在 profile %q，可用的 profile: %s",
+				getConfigPath(), profileName, strings.Join(configFile.ProfileNames(), ", "))
+		}
+		config.AccessKey = profile.AccessKeyID
+		config.SecretKey = profile.SecretAccessKey
+		config.Region = profile.Region
+		config.DomainID = profile.DomainID
+		config.ProjectID = profile.ProjectID
+		config.EnterpriseProjectID = profile.EnterpriseProjectID
+		config.MaxRetries = profile.MaxRetries
+		config.EnableRetry = profile.EnableRetry
+		config.LogFile = profile.LogFile
+		config.LogMaxSize = profile.LogMaxSize
+		config.LogMaxBackups = profile.LogMaxBackups
+		for service, endpoint := range profile.Endpoints {
+			config.Endpoints[strings.ToLower(service)] = normalizeEndpoint(endpoint)
+		}
+	} else if profileName != DefaultProfileName {
+		return nil, fmt.Errorf("profile %q 不存在：未找到配置文件 %s", profileName, getConfigPath())
 	}
 
 	// 2. 从环境变量覆盖
@@ -138,6 +221,7 @@ func LoadConfig(accessKeyFlag, secretKeyFlag, regionFlag, domainIDFlag string) (
 	if envEnterpriseProjectID := os.Getenv("HUAWEICLOUD_ENTERPRISE_PROJECT_ID"); envEnterpriseProjectID != "" {
 		config.EnterpriseProjectID = envEnterpriseProjectID
 	}
+	applyEndpointEnv(config.Endpoints)
 
 	// 3. 从命令行参数覆盖
 	if accessKeyFlag != "" {
@@ -234,6 +318,11 @@ func GetCredentials() (*Credentials, error) {
 		return nil, err
 	}
 
+	return credentialsFromConfig(config)
+}
+
+// credentialsFromConfig 校验配置并转换为凭证信息
+func credentialsFromConfig(config *Config) (*Credentials, error) {
 	if err := config.Validate(); err != nil {
 		return nil, err
 	}
@@ -261,18 +350,7 @@ func GetCredentialsWithFlags(accessKey, secretKey, region, domainID string) (*Cr
 		return nil, err
 	}
 
-	if err := config.Validate(); err != nil {
-		return nil, err
-	}
-
-	return &Credentials{
-		AccessKeyID:         config.AccessKey,
-		SecretAccessKey:     config.SecretKey,
-		Region:              config.Region,
-		DomainID:            config.DomainID,
-		ProjectID:           config.ProjectID,
-		EnterpriseProjectID: config.EnterpriseProjectID,
-	}, nil
+	return credentialsFromConfig(config)
 }
 
 // FetchProjects 获取项目列表
@@ -282,7 +360,7 @@ func (c *Config) FetchProjects() (*ProjectsResponse, error) {
 	}
 
 	// 构建请求URL
-	url := "https://iam.myhuaweicloud.com/v3/projects"
+	url := c.iamEndpoint() + "/v3/projects"
 
 	// 创建HTTP请求
 	req, err := http.NewRequest("GET", url, nil)
@@ -300,10 +378,10 @@ func (c *Config) FetchProjects() (*ProjectsResponse, error) {
 	}
 
 	// 发送请求
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := &http.Client{Timeout: 30 * time.Second, Transport: logx.HTTPTransport(nil)}
 	resp, err := client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("请求失败: %v", err)
+		return nil, hwErrors.NewNetworkError(fmt.Sprintf("请求 IAM 失败: %v", err))
 	}
 	defer resp.Body.Close()
 
@@ -315,7 +393,9 @@ func (c *Config) FetchProjects() (*ProjectsResponse, error) {
 
 	// 检查HTTP状态码
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API请求失败，状态码: %d, 响应: %s", resp.StatusCode, string(body))
+		apiErr := hwErrors.ParseHuaweiCloudError(resp.StatusCode, string(body))
+		apiErr.RequestID = resp.Header.Get("X-Request-Id")
+		return nil, apiErr
 	}
 
 	// 解析响应
@@ -11,19 +11,57 @@ import (
 func resetConfigPathEnv(t *testing.T) {
 	t.Helper()
 	prevOverride := configPathOverride
+	prevProfile := profileOverride
 	originalEnv := os.Getenv("HWCCTL_CONFIG")
+	originalProfileEnv := os.Getenv("HWCCTL_PROFILE")
 	t.Cleanup(func() {
 		configPathOverride = prevOverride
+		profileOverride = prevProfile
 		if originalEnv == "" {
 			os.Unsetenv("HWCCTL_CONFIG")
 		} else {
 			os.Setenv("HWCCTL_CONFIG", originalEnv)
 		}
+		if originalProfileEnv == "" {
+			os.Unsetenv("HWCCTL_PROFILE")
+		} else {
+			os.Setenv("HWCCTL_PROFILE", originalProfileEnv)
+		}
 	})
 	configPathOverride = ""
+	profileOverride = ""
 	os.Unsetenv("HWCCTL_CONFIG")
+	os.Unsetenv("HWCCTL_PROFILE")
 }
 
+// writeTestConfigFile 写入测试用配置文件并将其设为当前配置路径
+func writeTestConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config")
+	if err := os.WriteFile(path, []byte(content), 0600); err != nil {
+		t.Fatalf("写入测试配置文件失败: %v", err)
+	}
+	SetConfigPath(path)
+	return path
+}
+
+const testProfilesConfig = `default:
+  access_key_id: "default-ak"
+  secret_access_key: "default-sk"
+  region: "cn-north-4"
+profiles:
+  staging:
+    access_key_id: "staging-ak"
+    secret_access_key: "staging-sk"
+    region: "cn-east-3"
+    enable_retry: true
+    max_retries: 2
+  prod:
+    access_key_id: "prod-ak"
+    secret_access_key: "prod-sk"
+    region: "cn-south-1"
+`
+
 func TestConfigPathOverride(t *testing.T) {
 	resetConfigPathEnv(t)
 
@@ -69,6 +107,108 @@ func TestConfigPathDefaultHome(t *testing.T) {
 	}
 }
 
+func TestResolveProfileName(t *testing.T) {
+	resetConfigPathEnv(t)
+
+	if got := ResolveProfileName(); got != DefaultProfileName {
+		t.Fatalf("期望默认 profile 为 %s，实际为 %s", DefaultProfileName, got)
+	}
+
+	os.Setenv("HWCCTL_PROFILE", "staging")
+	if got := ResolveProfileName(); got != "staging" {
+		t.Fatalf("期望环境变量 profile 为 staging，实际为 %s", got)
+	}
+
+	// --profile 优先于环境变量
+	SetProfile("prod")
+	if got := ResolveProfileName(); got != "prod" {
+		t.Fatalf("期望覆盖 profile 为 prod，实际为 %s", got)
+	}
+}
+
+func TestLoadConfigNamedProfile(t *testing.T) {
+	resetConfigPathEnv(t)
+	t.Setenv("HUAWEICLOUD_ACCESS_KEY", "")
+	t.Setenv("HUAWEICLOUD_SECRET_KEY", "")
+	t.Setenv("HUAWEICLOUD_REGION", "")
+	writeTestConfigFile(t, testProfilesConfig)
+
+	config, err := LoadConfig("", "", "", "")
+	if err != nil {
+		t.Fatalf("加载默认 profile 失败: %v", err)
+	}
+	if config.AccessKey != "default-ak" || config.Region != "cn-north-4" {
+		t.Errorf("默认 profile 加载错误: %+v", config)
+	}
+
+	SetProfile("staging")
+	config, err = LoadConfig("", "", "", "")
+	if err != nil {
+		t.Fatalf("加载 staging profile 失败: %v", err)
+	}
+	if config.Profile != "staging" {
+		t.Errorf("期望 Profile 为 staging，实际为 %s", config.Profile)
+	}
+	if config.AccessKey != "staging-ak" || config.SecretKey != "staging-sk" || config.Region != "cn-east-3" {
+		t.Errorf("staging profile 凭证加载错误: %+v", config)
+	}
+	if !config.EnableRetry || config.MaxRetries != 2 {
+		t.Errorf("staging profile 重试设置加载错误: enable=%t max=%d", config.EnableRetry, config.MaxRetries)
+	}
+
+	// 命令行参数仍然优先于 profile
+	config, err = LoadConfig("", "", "ap-southeast-1", "")
+	if err != nil {
+		t.Fatalf("加载配置失败: %v", err)
+	}
+	if config.Region != "ap-southeast-1" {
+		t.Errorf("期望命令行区域覆盖 profile，实际为 %s", config.Region)
+	}
+}
+
+func TestLoadConfigUnknownProfile(t *testing.T) {
+	resetConfigPathEnv(t)
+	writeTestConfigFile(t, testProfilesConfig)
+
+	SetProfile("missing")
+	_, err := LoadConfig("", "", "", "")
+	if err == nil {
+		t.Fatal("期望不存在的 profile 返回错误")
+	}
+	if !strings.Contains(err.Error(), "missing") || !strings.Contains(err.Error(), "staging") {
+		t.Errorf("错误信息应包含 profile 名称和可用列表，实际为: %v", err)
+	}
+
+	// 配置文件不存在时指定命名 profile 同样报错
+	SetConfigPath(filepath.Join(t.TempDir(), "nonexistent"))
+	if _, err := LoadConfig("", "", "", ""); err == nil {
+		t.Error("期望配置文件不存在时指定 profile 返回错误")
+	}
+}
+
+func TestConfigFileProfiles(t *testing.T) {
+	cf := &ConfigFile{}
+	cf.SetProfile("default", Profile{Region: "cn-north-1"})
+	cf.SetProfile("partner", Profile{Region: "ap-southeast-1"})
+	cf.SetProfile("beta", Profile{Region: "cn-east-3"})
+
+	if cf.Default.Region != "cn-north-1" {
+		t.Errorf("期望 default profile 写入 Default 字段，实际为 %+v", cf.Default)
+	}
+	if p, ok := cf.GetProfile("partner"); !ok || p.Region != "ap-southeast-1" {
+		t.Errorf("获取 partner profile 失败: %+v, %t", p, ok)
+	}
+	if _, ok := cf.GetProfile("unknown"); ok {
+		t.Error("不存在的 profile 不应该返回 ok")
+	}
+
+	names := cf.ProfileNames()
+	expected := []string{"default", "beta", "partner"}
+	if strings.Join(names, ",") != strings.Join(expected, ",") {
+		t.Errorf("期望 profile 列表为 %v，实际为 %v", expected, names)
+	}
+}
+
 func TestNewConfig(t *testing.T) {
 	accessKey := "test-access-key"
 	secretKey := "test-secret-key"
@@ -0,0 +1,110 @@
+package auth
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// 支持端点覆盖的服务名称
+const (
+	ServiceCDN = "cdn"
+	ServiceIAM = "iam"
+)
+
+// 默认服务端点
+const (
+	DefaultIAMEndpoint = "https://iam.myhuaweicloud.com"
+)
+
+// KnownServices 支持端点覆盖的服务列表
+var KnownServices = []string{ServiceCDN, ServiceIAM}
+
+// endpointEnvName 返回服务端点覆盖的环境变量名，例如 HWCCTL_ENDPOINT_CDN
+func endpointEnvName(service string) string {
+	return "HWCCTL_ENDPOINT_" + strings.ToUpper(service)
+}
+
+// ParseEndpointOverrides 解析 --endpoint-url 的值
+// 支持两种写法：单个 URL（应用于所有服务），或逗号分隔的 service=url 列表
+func ParseEndpointOverrides(value string) (map[string]string, error) {
+	endpoints := make(map[string]string)
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return endpoints, nil
+	}
+
+	for _, item := range strings.Split(value, ",") {
+		item = strings.TrimSpace(item)
+		if item == "" {
+			continue
+		}
+
+		service, endpoint, found := strings.Cut(item, "=")
+		if !found {
+			// 单个 URL 应用于所有服务，便于指向本地 mock 服务
+			if err := validateEndpoint(item); err != nil {
+				return nil, err
+			}
+			for _, svc := range KnownServices {
+				endpoints[svc] = normalizeEndpoint(item)
+			}
+			continue
+		}
+
+		service = strings.ToLower(strings.TrimSpace(service))
+		endpoint = strings.TrimSpace(endpoint)
+		if service == "" {
+			return nil, fmt.Errorf("端点覆盖 %q 缺少服务名称", item)
+		}
+		if err := validateEndpoint(endpoint); err != nil {
+			return nil, err
+		}
+		endpoints[service] = normalizeEndpoint(endpoint)
+	}
+
+	return endpoints, nil
+}
+
+// validateEndpoint 校验端点 URL 格式
+func validateEndpoint(endpoint string) error {
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return fmt.Errorf("无效的端点 URL %q: %v", endpoint, err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("无效的端点 URL %q：必须是 http:// 或 https:// 开头的完整地址", endpoint)
+	}
+	return nil
+}
+
+// normalizeEndpoint 去除端点 URL 末尾的斜杠
+func normalizeEndpoint(endpoint string) string {
+	return strings.TrimRight(strings.TrimSpace(endpoint), "/")
+}
+
+// applyEndpointEnv 使用 HWCCTL_ENDPOINT_<SERVICE> 环境变量覆盖端点
+func applyEndpointEnv(endpoints map[string]string) {
+	for _, service := range KnownServices {
+		if envEndpoint := strings.TrimSpace(os.Getenv(endpointEnvName(service))); envEndpoint != "" {
+			endpoints[service] = normalizeEndpoint(envEndpoint)
+		}
+	}
+}
+
+// Endpoint 返回指定服务的端点覆盖，未配置时返回空字符串
+func (c *Config) Endpoint(service string) string {
+	if c.Endpoints == nil {
+		return ""
+	}
+	return c.Endpoints[strings.ToLower(service)]
+}
+
+// iamEndpoint 返回 IAM 服务端点，未覆盖时使用默认端点
+func (c *Config) iamEndpoint() string {
+	if endpoint := c.Endpoint(ServiceIAM); endpoint != "" {
+		return endpoint
+	}
+	return DefaultIAMEndpoint
+}
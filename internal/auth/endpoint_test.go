@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseEndpointOverrides(t *testing.T) {
+	tests := []struct {
+		name        string
+		value       string
+		expected    map[string]string
+		expectError bool
+	}{
+		{
+			name:     "空值",
+			value:    "",
+			expected: map[string]string{},
+		},
+		{
+			name:  "单个URL应用于所有服务",
+			value: "http://127.0.0.1:8080/",
+			expected: map[string]string{
+				ServiceCDN: "http://127.0.0.1:8080",
+				ServiceIAM: "http://127.0.0.1:8080",
+			},
+		},
+		{
+			name:  "按服务指定",
+			value: "CDN=https://cdn.example.com, iam=https://iam.example.com",
+			expected: map[string]string{
+				ServiceCDN: "https://cdn.example.com",
+				ServiceIAM: "https://iam.example.com",
+			},
+		},
+		{
+			name:        "缺少协议",
+			value:       "cdn=cdn.example.com",
+			expectError: true,
+		},
+		{
+			name:        "缺少服务名称",
+			value:       "=https://cdn.example.com",
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoints, err := ParseEndpointOverrides(tt.value)
+			if tt.expectError {
+				if err == nil {
+					t.Errorf("期望返回错误，实际结果: %v", endpoints)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("不期望错误: %v", err)
+			}
+			if len(endpoints) != len(tt.expected) {
+				t.Fatalf("期望 %v，实际 %v", tt.expected, endpoints)
+			}
+			for service, endpoint := range tt.expected {
+				if endpoints[service] != endpoint {
+					t.Errorf("服务 %s 期望端点 %s，实际 %s", service, endpoint, endpoints[service])
+				}
+			}
+		})
+	}
+}
+
+func TestEndpointPrecedence(t *testing.T) {
+	resetConfigPathEnv(t)
+	writeTestConfigFile(t, `default:
+  access_key_id: "ak"
+  secret_access_key: "sk"
+  endpoints:
+    cdn: "https://cdn.profile.example.com/"
+    iam: "https://iam.profile.example.com"
+`)
+	t.Setenv("HWCCTL_ENDPOINT_CDN", "")
+	t.Setenv("HWCCTL_ENDPOINT_IAM", "https://iam.env.example.com")
+
+	session, err := NewSession(SessionOptions{})
+	if err != nil {
+		t.Fatalf("创建会话失败: %v", err)
+	}
+	if got := session.Endpoint(ServiceCDN); got != "https://cdn.profile.example.com" {
+		t.Errorf("期望使用 profile 中的 CDN 端点，实际为 %s", got)
+	}
+	if got := session.Endpoint(ServiceIAM); got != "https://iam.env.example.com" {
+		t.Errorf("期望环境变量覆盖 IAM 端点，实际为 %s", got)
+	}
+
+	session, err = NewSession(SessionOptions{EndpointURL: "cdn=http://localhost:9000"})
+	if err != nil {
+		t.Fatalf("创建会话失败: %v", err)
+	}
+	if got := session.Endpoint(ServiceCDN); got != "http://localhost:9000" {
+		t.Errorf("期望命令行参数覆盖 CDN 端点，实际为 %s", got)
+	}
+	if got := session.Endpoint(ServiceIAM); got != "https://iam.env.example.com" {
+		t.Errorf("未指定的服务应保留原端点，实际为 %s", got)
+	}
+
+	if _, err := NewSession(SessionOptions{EndpointURL: "not-a-url"}); err == nil {
+		t.Error("期望无效的端点 URL 返回错误")
+	}
+}
+
+func TestFetchProjectsEndpointOverride(t *testing.T) {
+	var requestedPath, authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requestedPath = r.URL.Path
+		authorization = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"projects":[{"id":"p-123","name":"cn-north-4","enabled":true}]}`))
+	}))
+	defer server.Close()
+
+	config := &Config{
+		AccessKey: "test-ak",
+		SecretKey: "test-sk",
+		Region:    "cn-north-4",
+		Endpoints: map[string]string{ServiceIAM: server.URL},
+	}
+
+	projectID, err := config.GetProjectIDByRegion("cn-north-4")
+	if err != nil {
+		t.Fatalf("通过覆盖端点获取项目失败: %v", err)
+	}
+	if projectID != "p-123" {
+		t.Errorf("期望项目ID为 p-123，实际为 %s", projectID)
+	}
+	if requestedPath != "/v3/projects" {
+		t.Errorf("期望请求路径为 /v3/projects，实际为 %s", requestedPath)
+	}
+	if authorization == "" {
+		t.Error("请求应携带签名头")
+	}
+}
@@ -7,6 +7,8 @@ import (
 	"net/http"
 	"sync"
 	"time"
+
+	"github.com/ygqygq2/hwcctl/internal/logx"
 )
 
 // ProjectManager 项目管理器，负责统一管理项目ID
@@ -100,9 +102,9 @@ func (pm *ProjectManager) GetProjectID() (string, error) {
 
 // fetchProjectIDFromAPI 从华为云API获取项目ID
 func (pm *ProjectManager) fetchProjectIDFromAPI() (string, error) {
-	url := "https://iam.myhuaweicloud.com/v3/projects"
+	url := pm.config.iamEndpoint() + "/v3/projects"
 
-	client := &http.Client{Timeout: 30 * time.Second}
+	client := &http.Client{Timeout: 30 * time.Second, Transport: logx.HTTPTransport(nil)}
 	req, err := http.NewRequest("GET", url, nil)
 	if err != nil {
 		return "", fmt.Errorf("创建请求失败: %v", err)
@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SessionOptions 创建会话时来自命令行参数的覆盖项
+type SessionOptions struct {
+	AccessKeyID     string
+	SecretAccessKey string
+	Region          string
+	DomainID        string
+	EndpointURL     string // --endpoint-url 的值，单个 URL 或逗号分隔的 service=url 列表
+}
+
+// Session 单次命令执行期间解析完成的会话信息
+// 在命令开始时按 命令行参数 > 环境变量 > profile 的优先级解析一次，之后传递给各服务客户端
+type Session struct {
+	config *Config
+}
+
+// NewSession 根据命令行参数、环境变量和选定的 profile 创建会话
+func NewSession(opts SessionOptions) (*Session, error) {
+	config, err := LoadConfig(opts.AccessKeyID, opts.SecretAccessKey, opts.Region, opts.DomainID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 命令行端点覆盖优先级最高
+	overrides, err := ParseEndpointOverrides(opts.EndpointURL)
+	if err != nil {
+		return nil, err
+	}
+	for service, endpoint := range overrides {
+		config.Endpoints[service] = endpoint
+	}
+	for service, endpoint := range config.Endpoints {
+		if err := validateEndpoint(endpoint); err != nil {
+			return nil, fmt.Errorf("服务 %s 的端点配置错误: %v", service, err)
+		}
+	}
+
+	return &Session{config: config}, nil
+}
+
+// NewSessionFromConfig 使用已有配置创建会话（主要用于测试和嵌入场景）
+func NewSessionFromConfig(config *Config) *Session {
+	return &Session{config: config}
+}
+
+// Config 返回会话使用的完整配置
+func (s *Session) Config() *Config {
+	return s.config
+}
+
+// Profile 返回会话使用的 profile 名称
+func (s *Session) Profile() string {
+	return s.config.Profile
+}
+
+// Region 返回会话使用的区域
+func (s *Session) Region() string {
+	return s.config.Region
+}
+
+// Endpoint 返回指定服务的端点覆盖，未配置时返回空字符串
+func (s *Session) Endpoint(service string) string {
+	if s == nil || s.config == nil {
+		return ""
+	}
+	return s.config.Endpoint(service)
+}
+
+// Credentials 校验并返回会话中的认证凭证
+func (s *Session) Credentials() (*Credentials, error) {
+	if s == nil || s.config == nil {
+		return nil, errors.New("会话未初始化")
+	}
+	return credentialsFromConfig(s.config)
+}
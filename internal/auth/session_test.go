@@ -0,0 +1,53 @@
+package auth
+
+import (
+	"testing"
+)
+
+func TestNewSessionPrecedence(t *testing.T) {
+	resetConfigPathEnv(t)
+	writeTestConfigFile(t, testProfilesConfig)
+	t.Setenv("HUAWEICLOUD_ACCESS_KEY", "")
+	t.Setenv("HUAWEICLOUD_SECRET_KEY", "")
+	t.Setenv("HUAWEICLOUD_REGION", "cn-east-2")
+	t.Setenv("HUAWEICLOUD_DOMAIN_ID", "")
+
+	SetProfile("prod")
+	session, err := NewSession(SessionOptions{AccessKeyID: "flag-ak"})
+	if err != nil {
+		t.Fatalf("创建会话失败: %v", err)
+	}
+
+	if session.Profile() != "prod" {
+		t.Errorf("期望 profile 为 prod，实际为 %s", session.Profile())
+	}
+	// 环境变量覆盖 profile
+	if session.Region() != "cn-east-2" {
+		t.Errorf("期望环境变量区域 cn-east-2，实际为 %s", session.Region())
+	}
+
+	creds, err := session.Credentials()
+	if err != nil {
+		t.Fatalf("获取凭证失败: %v", err)
+	}
+	// 命令行参数覆盖环境变量和 profile
+	if creds.AccessKeyID != "flag-ak" {
+		t.Errorf("期望命令行 AccessKey flag-ak，实际为 %s", creds.AccessKeyID)
+	}
+	// 未覆盖的字段来自 profile
+	if creds.SecretAccessKey != "prod-sk" {
+		t.Errorf("期望 profile SecretKey prod-sk，实际为 %s", creds.SecretAccessKey)
+	}
+}
+
+func TestSessionCredentialsValidation(t *testing.T) {
+	var nilSession *Session
+	if _, err := nilSession.Credentials(); err == nil {
+		t.Error("期望未初始化会话返回错误")
+	}
+
+	session := NewSessionFromConfig(&Config{Region: "cn-north-1"})
+	if _, err := session.Credentials(); err == nil {
+		t.Error("期望缺少 AccessKey 时返回错误")
+	}
+}
@@ -0,0 +1,259 @@
+package cdn
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2/model"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/retry"
+)
+
+// 单次请求允许提交的最大 URL 数量
+const (
+	// MaxRefreshURLsPerRequest 单次文件刷新请求允许的最大 URL 数量
+	MaxRefreshURLsPerRequest = 1000
+	// MaxRefreshDirectoriesPerRequest 单次目录刷新请求允许的最大目录数量
+	MaxRefreshDirectoriesPerRequest = 100
+	// MaxPreloadURLsPerRequest 单次预热请求允许的最大 URL 数量
+	MaxPreloadURLsPerRequest = 1000
+	// DefaultBatchConcurrency 默认同时提交的批次数量
+	DefaultBatchConcurrency = 3
+)
+
+// 配额类型，对应配额接口返回的 type 字段
+const (
+	QuotaTypeURLRefresh = "url_refresh"
+	QuotaTypeDirRefresh = "dir_refresh"
+	QuotaTypeURLPreload = "url_preheat"
+)
+
+// Quota CDN 配额信息
+type Quota struct {
+	Type      string `json:"type" table:"配额类型"`
+	Limit     int    `json:"limit" table:"配额上限"`
+	Used      int    `json:"used" table:"已使用"`
+	Remaining int    `json:"remaining" table:"剩余"`
+}
+
+// BatchOptions 分批提交参数
+type BatchOptions struct {
+	// BatchSize 每批 URL 数量，小于等于 0 或超过接口上限时使用接口上限
+	BatchSize int
+	// Concurrency 同时提交的批次数量，小于等于 0 时使用默认值
+	Concurrency int
+	// SkipQuotaCheck 为 true 时跳过提交前的配额检查
+	SkipQuotaCheck bool
+	// Retryer 单个批次提交失败时的重试器，为 nil 时不重试
+	Retryer *retry.Retryer
+}
+
+// BatchTask 单个批次的提交结果
+type BatchTask struct {
+	TaskID string   `json:"task_id,omitempty" table:"任务ID"`
+	URLs   []string `json:"urls" table:"URL列表"`
+	Error  string   `json:"error,omitempty" table:"错误"`
+}
+
+// BatchResult 分批提交的汇总结果
+type BatchResult struct {
+	// TaskID 只有一个批次时等于该批次的任务ID，兼容未分批时的输出
+	TaskID    string      `json:"task_id,omitempty"`
+	Type      string      `json:"type"`
+	TaskIDs   []string    `json:"task_ids"`
+	URLCount  int         `json:"url_count"`
+	Batches   []BatchTask `json:"batches"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// FailedBatches 返回提交失败的批次数量
+func (r *BatchResult) FailedBatches() int {
+	failed := 0
+	for _, batch := range r.Batches {
+		if batch.Error != "" {
+			failed++
+		}
+	}
+	return failed
+}
+
+// GetQuotas 查询账号的 CDN 配额
+func (c *Client) GetQuotas() ([]Quota, error) {
+	response, err := c.cdnClient.ShowQuota(&model.ShowQuotaRequest{})
+	if err != nil {
+		logx.Debugf("查询 CDN 配额失败: %v", err)
+		return nil, hwErrors.FromSDKError(err)
+	}
+
+	quotas := []Quota{}
+	if response.Quotas == nil {
+		return quotas, nil
+	}
+	for _, q := range *response.Quotas {
+		quota := Quota{
+			Type:  getStringValue(q.Type),
+			Limit: getIntValue(q.QuotaLimit),
+			Used:  getIntValue(q.Used),
+		}
+		quota.Remaining = quota.Limit - quota.Used
+		if quota.Remaining < 0 {
+			quota.Remaining = 0
+		}
+		quotas = append(quotas, quota)
+	}
+	return quotas, nil
+}
+
+// checkQuota 检查指定类型的剩余配额是否足够提交 count 个 URL
+// 配额接口未返回该类型或查询失败时不做限制，只有明确超出配额时才返回错误
+// 认证失败时后续提交同样会失败，直接返回错误
+func (c *Client) checkQuota(quotaType string, count int) error {
+	quotas, err := c.GetQuotas()
+	if err != nil {
+		if hwErrors.AsHuaweiCloudError(err).Type == hwErrors.ErrorTypeAuth {
+			return err
+		}
+		logx.Warn("查询 CDN 配额失败，跳过配额检查", logx.KeyError, err)
+		return nil
+	}
+
+	for _, quota := range quotas {
+		if quota.Type != quotaType {
+			continue
+		}
+		logx.Debugf("配额 %s: 上限 %d，已使用 %d，剩余 %d", quota.Type, quota.Limit, quota.Used, quota.Remaining)
+		if count > quota.Remaining {
+			return hwErrors.NewQuotaExceededError(fmt.Sprintf(
+				"今日 %s 配额不足: 本次需要 %d，剩余 %d（上限 %d，已使用 %d）",
+				quotaType, count, quota.Remaining, quota.Limit, quota.Used))
+		}
+		return nil
+	}
+
+	logx.Debugf("配额接口未返回 %s 类型的配额，跳过检查", quotaType)
+	return nil
+}
+
+// splitBatches 将 URL 列表按 size 拆分为多个批次
+func splitBatches(urls []string, size int) [][]string {
+	if size <= 0 {
+		size = len(urls)
+	}
+	var batches [][]string
+	for start := 0; start < len(urls); start += size {
+		end := min(start+size, len(urls))
+		batches = append(batches, urls[start:end])
+	}
+	return batches
+}
+
+// submitBatches 以有限并发提交所有批次，结果顺序与批次顺序一致
+// 部分批次失败时仍返回全部批次的结果，并返回汇总错误
+func submitBatches(ctx context.Context, batches [][]string, opts BatchOptions, submit func([]string) (string, error)) ([]BatchTask, error) {
+	concurrency := opts.Concurrency
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	results := make([]BatchTask, len(batches))
+	errs := make([]error, len(batches))
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+
+	for i, batch := range batches {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int, batch []string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+
+			var taskID string
+			run := func() error {
+				id, err := submit(batch)
+				if err != nil {
+					return err
+				}
+				taskID = id
+				return nil
+			}
+
+			var err error
+			if opts.Retryer != nil {
+				err = opts.Retryer.Do(ctx, run)
+			} else {
+				err = run()
+			}
+
+			results[i] = BatchTask{TaskID: taskID, URLs: batch}
+			if err != nil {
+				logx.Debugf("第 %d/%d 批提交失败: %v", i+1, len(batches), err)
+				results[i].Error = err.Error()
+				errs[i] = err
+				return
+			}
+			logx.Debugf("第 %d/%d 批提交成功，任务ID: %s，URL 数量: %d", i+1, len(batches), taskID, len(batch))
+		}(i, batch)
+	}
+	wg.Wait()
+
+	// 汇总错误沿用第一个失败批次的错误类型和错误码，便于调用方判断退出码
+	failed, first := 0, -1
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		failed++
+		if first < 0 {
+			first = i
+		}
+	}
+	if failed > 0 {
+		return results, hwErrors.Wrap(errs[first],
+			fmt.Sprintf("%d/%d 个批次提交失败，第 %d 批", failed, len(batches), first+1))
+	}
+	return results, nil
+}
+
+// runBatches 检查配额后分批提交 URL，并汇总所有批次的任务ID
+func (c *Client) runBatches(ctx context.Context, taskType, quotaType string, urls []string, limit int, opts BatchOptions,
+	submit func([]string) (string, error)) (*BatchResult, error) {
+	if len(urls) == 0 {
+		return nil, hwErrors.NewValidationError("URL 列表不能为空")
+	}
+
+	if !opts.SkipQuotaCheck {
+		if err := c.checkQuota(quotaType, len(urls)); err != nil {
+			return nil, err
+		}
+	}
+
+	batchSize := opts.BatchSize
+	if batchSize <= 0 || batchSize > limit {
+		batchSize = limit
+	}
+	batches := splitBatches(urls, batchSize)
+	if len(batches) > 1 {
+		logx.Infof("共 %d 个 URL，按每批 %d 个拆分为 %d 批提交", len(urls), batchSize, len(batches))
+	}
+
+	tasks, err := submitBatches(ctx, batches, opts, submit)
+	result := &BatchResult{
+		Type:      taskType,
+		TaskIDs:   []string{},
+		URLCount:  len(urls),
+		Batches:   tasks,
+		CreatedAt: time.Now(),
+	}
+	for _, task := range tasks {
+		if task.TaskID != "" {
+			result.TaskIDs = append(result.TaskIDs, task.TaskID)
+		}
+	}
+	if len(tasks) == 1 {
+		result.TaskID = tasks[0].TaskID
+	}
+	return result, err
+}
@@ -0,0 +1,268 @@
+package cdn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+)
+
+// testURLs 生成 n 个测试 URL
+func testURLs(n int) []string {
+	urls := make([]string, n)
+	for i := range urls {
+		urls[i] = fmt.Sprintf("https://example.com/file-%d.js", i)
+	}
+	return urls
+}
+
+func TestSplitBatches(t *testing.T) {
+	batches := splitBatches(testURLs(5), 2)
+	if len(batches) != 3 {
+		t.Fatalf("期望 3 批，实际为 %d", len(batches))
+	}
+	if len(batches[0]) != 2 || len(batches[2]) != 1 {
+		t.Errorf("批次大小错误: %d, %d", len(batches[0]), len(batches[2]))
+	}
+	if len(splitBatches(nil, 2)) != 0 {
+		t.Error("空列表不应该产生批次")
+	}
+}
+
+func TestSubmitBatchesConcurrencyAndOrder(t *testing.T) {
+	var running, peak int32
+	batches := splitBatches(testURLs(10), 1)
+
+	results, err := submitBatches(context.Background(), batches, BatchOptions{Concurrency: 2}, func(batch []string) (string, error) {
+		current := atomic.AddInt32(&running, 1)
+		for {
+			old := atomic.LoadInt32(&peak)
+			if current <= old || atomic.CompareAndSwapInt32(&peak, old, current) {
+				break
+			}
+		}
+		time.Sleep(5 * time.Millisecond)
+		atomic.AddInt32(&running, -1)
+		return "task-" + batch[0], nil
+	})
+	if err != nil {
+		t.Fatalf("提交失败: %v", err)
+	}
+	if peak > 2 {
+		t.Errorf("并发数不应超过 2，实际峰值为 %d", peak)
+	}
+	for i, result := range results {
+		if result.TaskID != "task-"+batches[i][0] {
+			t.Errorf("第 %d 批结果顺序错误: %s", i+1, result.TaskID)
+		}
+	}
+}
+
+func TestSubmitBatchesPartialFailure(t *testing.T) {
+	batches := splitBatches(testURLs(3), 1)
+	results, err := submitBatches(context.Background(), batches, BatchOptions{}, func(batch []string) (string, error) {
+		if batch[0] == batches[1][0] {
+			return "", hwErrors.NewPermissionError("无权限")
+		}
+		return "task-" + batch[0], nil
+	})
+	if err == nil {
+		t.Fatal("期望部分批次失败时返回错误")
+	}
+
+	var hwErr *hwErrors.HuaweiCloudError
+	if !errors.As(err, &hwErr) || hwErr.Type != hwErrors.ErrorTypePermission {
+		t.Errorf("期望保留原始错误类型，实际为 %v", err)
+	}
+	if hwErr != nil && hwErr.Message != "1/3 个批次提交失败，第 2 批: 无权限" {
+		t.Errorf("结构化错误应包含汇总信息，实际为 %q", hwErr.Message)
+	}
+	if results[0].TaskID == "" || results[2].TaskID == "" || results[1].Error == "" {
+		t.Errorf("批次结果错误: %+v", results)
+	}
+}
+
+// newBatchTestHandler 创建同时响应配额和刷新/预热接口的 mock 服务
+func newBatchTestHandler(t *testing.T, quotaType string, limit, used int, submitted *[][]string) http.HandlerFunc {
+	var mu sync.Mutex
+	return func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/v1.0/cdn/quota"):
+			writeJSON(t, w, map[string]interface{}{
+				"quotas": []map[string]interface{}{
+					{"type": "domain", "quota_limit": 100, "used": 1},
+					{"type": quotaType, "quota_limit": limit, "used": used},
+				},
+			})
+		case strings.HasSuffix(r.URL.Path, "/refresh-tasks"), strings.HasSuffix(r.URL.Path, "/preheating-tasks"):
+			var body map[string]struct {
+				Urls []string `json:"urls"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("解析请求体失败: %v", err)
+			}
+			mu.Lock()
+			defer mu.Unlock()
+			for key, task := range body {
+				*submitted = append(*submitted, task.Urls)
+				id := fmt.Sprintf("task-%d", len(*submitted))
+				if key == "refresh_task" {
+					writeJSON(t, w, map[string]interface{}{"refresh_task": id})
+				} else {
+					writeJSON(t, w, map[string]interface{}{"preheating_task": id})
+				}
+			}
+		default:
+			t.Errorf("未预期的请求: %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func TestRefreshCacheBatches(t *testing.T) {
+	var submitted [][]string
+	client := newTestClient(t, newBatchTestHandler(t, QuotaTypeDirRefresh, 1000, 0, &submitted))
+
+	dirs := make([]string, 250)
+	for i := range dirs {
+		dirs[i] = fmt.Sprintf("https://example.com/dir-%d/", i)
+	}
+
+	result, err := client.RefreshCache(context.Background(), dirs, "directory", BatchOptions{})
+	if err != nil {
+		t.Fatalf("刷新失败: %v", err)
+	}
+	// 目录刷新单次上限为 100，250 个目录应拆分为 3 批
+	if len(submitted) != 3 || len(result.TaskIDs) != 3 {
+		t.Fatalf("期望提交 3 批，实际提交 %d 批，任务 %v", len(submitted), result.TaskIDs)
+	}
+	total := 0
+	for _, batch := range submitted {
+		if len(batch) > MaxRefreshDirectoriesPerRequest {
+			t.Errorf("单批数量 %d 超过上限", len(batch))
+		}
+		total += len(batch)
+	}
+	if total != 250 || result.URLCount != 250 {
+		t.Errorf("期望提交 250 个目录，实际为 %d", total)
+	}
+	if result.TaskID != "" {
+		t.Errorf("多个批次时不应设置 task_id，实际为 %s", result.TaskID)
+	}
+}
+
+func TestPreloadCacheSingleBatch(t *testing.T) {
+	var submitted [][]string
+	client := newTestClient(t, newBatchTestHandler(t, QuotaTypeURLPreload, 1000, 0, &submitted))
+
+	result, err := client.PreloadCache(context.Background(), testURLs(3), BatchOptions{BatchSize: 5000})
+	if err != nil {
+		t.Fatalf("预热失败: %v", err)
+	}
+	if len(submitted) != 1 || result.TaskID != "task-1" || result.Type != "preload" {
+		t.Errorf("单批结果错误: %+v", result)
+	}
+}
+
+func TestRefreshCacheQuotaExceeded(t *testing.T) {
+	var submitted [][]string
+	client := newTestClient(t, newBatchTestHandler(t, QuotaTypeURLRefresh, 2000, 1995, &submitted))
+
+	_, err := client.RefreshCache(context.Background(), testURLs(10), "url", BatchOptions{})
+	if err == nil {
+		t.Fatal("期望配额不足时返回错误")
+	}
+	var hwErr *hwErrors.HuaweiCloudError
+	if !errors.As(err, &hwErr) || hwErr.Code != "QuotaExceeded" {
+		t.Errorf("期望配额不足错误，实际为 %v", err)
+	}
+	if !strings.Contains(err.Error(), "剩余 5") {
+		t.Errorf("错误信息应包含剩余配额，实际为 %v", err)
+	}
+	if len(submitted) != 0 {
+		t.Errorf("配额不足时不应提交任务，实际提交 %d 批", len(submitted))
+	}
+
+	// 跳过配额检查时直接提交
+	if _, err := client.RefreshCache(context.Background(), testURLs(10), "url", BatchOptions{SkipQuotaCheck: true}); err != nil {
+		t.Errorf("跳过配额检查后刷新失败: %v", err)
+	}
+}
+
+func TestRefreshCacheQuotaLookupFailed(t *testing.T) {
+	var submitted [][]string
+	quotaHandler := newBatchTestHandler(t, QuotaTypeURLRefresh, 2000, 0, &submitted)
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/v1.0/cdn/quota") {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusInternalServerError)
+			writeJSON(t, w, map[string]interface{}{"error_code": "CDN.0001", "error_msg": "internal error"})
+			return
+		}
+		quotaHandler(w, r)
+	})
+
+	var buf bytes.Buffer
+	logx.SetOutput(&buf)
+	t.Cleanup(func() { logx.SetOutput(os.Stderr) })
+
+	// 配额查询失败时只记录警告，不阻止提交
+	if _, err := client.RefreshCache(context.Background(), testURLs(10), "url", BatchOptions{}); err != nil {
+		t.Fatalf("配额查询失败时应继续刷新，实际返回 %v", err)
+	}
+	if len(submitted) != 1 {
+		t.Errorf("期望提交 1 批，实际提交 %d 批", len(submitted))
+	}
+	if !strings.Contains(buf.String(), "跳过配额检查") {
+		t.Errorf("配额查询失败时应输出警告，实际日志: %s", buf.String())
+	}
+}
+
+func TestRefreshCacheInvalidType(t *testing.T) {
+	var submitted [][]string
+	client := newTestClient(t, newBatchTestHandler(t, QuotaTypeURLRefresh, 2000, 0, &submitted))
+	if _, err := client.RefreshCache(context.Background(), testURLs(1), "invalid", BatchOptions{}); err == nil {
+		t.Error("期望不支持的刷新类型返回错误")
+	}
+}
+
+func TestRefreshCacheDebugHTTP(t *testing.T) {
+	var logs bytes.Buffer
+	logx.SetOutput(&logs)
+	logx.SetLevel("debug")
+	logx.SetHTTPDebug(true)
+	t.Cleanup(func() {
+		logx.SetOutput(os.Stderr)
+		logx.SetLevel("warn")
+		logx.SetHTTPDebug(false)
+	})
+
+	var submitted [][]string
+	client := newTestClient(t, newBatchTestHandler(t, QuotaTypeURLRefresh, 2000, 0, &submitted))
+	if _, err := client.RefreshCache(context.Background(), testURLs(1), "url", BatchOptions{SkipQuotaCheck: true}); err != nil {
+		t.Fatalf("刷新失败: %v", err)
+	}
+
+	// 记录的是 SDK 实际发出的请求，Authorization 中的签名需要脱敏
+	output := logs.String()
+	if !strings.Contains(output, "method=POST") || !strings.Contains(output, "/v1.0/cdn/content/refresh-tasks") {
+		t.Errorf("应记录实际发送的刷新请求: %s", output)
+	}
+	if !strings.Contains(output, "Authorization:******") || strings.Contains(output, "Signature=") {
+		t.Errorf("Authorization 应脱敏: %s", output)
+	}
+	if strings.Contains(output, "请求URL将会是") {
+		t.Error("不应再输出硬编码的请求地址")
+	}
+}
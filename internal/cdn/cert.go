@@ -0,0 +1,272 @@
+package cdn
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2/model"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+)
+
+// certificateListPageSize 查询证书时的每页数量
+const certificateListPageSize = 100
+
+// 证书名称长度限制
+const (
+	minCertNameLength = 3
+	maxCertNameLength = 64
+)
+
+// CertificateInfo 本地解析得到的证书信息
+type CertificateInfo struct {
+	Subject     string   `json:"subject" table:"主题"`
+	Issuer      string   `json:"issuer" table:"签发者"`
+	DNSNames    []string `json:"dns_names" table:"域名列表"`
+	NotBefore   string   `json:"not_before" table:"生效时间"`
+	NotAfter    string   `json:"not_after" table:"过期时间"`
+	DaysLeft    int      `json:"days_left" table:"剩余天数"`
+	ChainLength int      `json:"chain_length" table:"证书链长度"`
+}
+
+// DomainCertificate 加速域名的 HTTPS 证书信息
+type DomainCertificate struct {
+	Domain    string `json:"domain_name" table:"域名"`
+	CertName  string `json:"cert_name" table:"证书名称"`
+	HTTPS     bool   `json:"https" table:"HTTPS"`
+	ExpiresAt string `json:"expires_at,omitempty" table:"过期时间"`
+	DaysLeft  int    `json:"days_left" table:"剩余天数"`
+}
+
+// CertificateListOptions 证书查询条件
+type CertificateListOptions struct {
+	Domain string // 域名，模糊匹配
+	// ExpiringWithin 大于 0 时只返回在该时长内过期（含已过期）的证书
+	ExpiringWithin time.Duration
+	// Now 计算剩余天数的基准时间，为零值时使用当前时间
+	Now time.Time
+}
+
+// ValidateCertificate 在本地校验证书和私钥
+// 检查证书链顺序、私钥与证书是否匹配、证书是否覆盖 domain 以及是否在有效期内
+func ValidateCertificate(certPEM, keyPEM []byte, domain string, now time.Time) (*CertificateInfo, error) {
+	certs, err := parseCertificateChain(certPEM)
+	if err != nil {
+		return nil, hwErrors.NewValidationError(err.Error())
+	}
+	leaf := certs[0]
+
+	for i := 0; i+1 < len(certs); i++ {
+		if err := certs[i].CheckSignatureFrom(certs[i+1]); err != nil {
+			return nil, hwErrors.NewValidationError(fmt.Sprintf(
+				"证书链顺序错误: 第 %d 个证书不是由第 %d 个证书签发，请按 服务器证书、中间证书 的顺序排列", i+1, i+2))
+		}
+	}
+
+	if _, err := tls.X509KeyPair(certPEM, keyPEM); err != nil {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("私钥与证书不匹配或私钥格式无效: %v", err))
+	}
+
+	if domain != "" && !certificateCoversDomain(leaf, domain) {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("证书不包含域名 %s，证书域名: %s",
+			domain, strings.Join(leaf.DNSNames, ", ")))
+	}
+
+	if now.Before(leaf.NotBefore) {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("证书尚未生效，生效时间: %s",
+			leaf.NotBefore.Local().Format("2006-01-02 15:04:05")))
+	}
+	if now.After(leaf.NotAfter) {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("证书已过期，过期时间: %s",
+			leaf.NotAfter.Local().Format("2006-01-02 15:04:05")))
+	}
+
+	return &CertificateInfo{
+		Subject:     leaf.Subject.CommonName,
+		Issuer:      leaf.Issuer.CommonName,
+		DNSNames:    leaf.DNSNames,
+		NotBefore:   leaf.NotBefore.Local().Format("2006-01-02 15:04:05"),
+		NotAfter:    leaf.NotAfter.Local().Format("2006-01-02 15:04:05"),
+		DaysLeft:    daysUntil(leaf.NotAfter, now),
+		ChainLength: len(certs),
+	}, nil
+}
+
+// parseCertificateChain 解析 PEM 编码的证书链
+func parseCertificateChain(data []byte) ([]*x509.Certificate, error) {
+	var certs []*x509.Certificate
+	for {
+		var block *pem.Block
+		block, data = pem.Decode(data)
+		if block == nil {
+			break
+		}
+		if block.Type != "CERTIFICATE" {
+			return nil, fmt.Errorf("证书文件中包含非证书内容: %s", block.Type)
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("解析第 %d 个证书失败: %v", len(certs)+1, err)
+		}
+		certs = append(certs, cert)
+	}
+	if len(certs) == 0 {
+		return nil, fmt.Errorf("证书文件中没有 PEM 格式的证书")
+	}
+	return certs, nil
+}
+
+// certificateCoversDomain 判断证书是否覆盖域名，泛域名需要证书中包含相同的泛域名
+func certificateCoversDomain(cert *x509.Certificate, domain string) bool {
+	domain = strings.ToLower(strings.TrimSuffix(domain, "."))
+	if strings.HasPrefix(domain, "*.") {
+		for _, name := range cert.DNSNames {
+			if strings.EqualFold(name, domain) {
+				return true
+			}
+		}
+		return false
+	}
+	return cert.VerifyHostname(domain) == nil
+}
+
+// daysUntil 返回距离 t 的整天数，已过去时为负数
+func daysUntil(t, now time.Time) int {
+	return int(t.Sub(now).Hours() / 24)
+}
+
+// DefaultCertName 生成默认证书名称，格式为 域名-过期日期
+func DefaultCertName(domain string, info *CertificateInfo) string {
+	name := strings.ReplaceAll(domain, "*", "wildcard")
+	if info != nil && len(info.NotAfter) >= len("2006-01-02") {
+		name += "-" + strings.ReplaceAll(info.NotAfter[:len("2006-01-02")], "-", "")
+	}
+	if len(name) > maxCertNameLength {
+		name = name[len(name)-maxCertNameLength:]
+	}
+	return name
+}
+
+// SetCertificate 为加速域名设置自有证书并开启 HTTPS
+// 保留线上的 HTTP/2、TLS 版本和 OCSP Stapling 配置，只替换证书
+func (c *Client) SetCertificate(domain, certName string, certPEM, keyPEM []byte) error {
+	if len(certName) < minCertNameLength || len(certName) > maxCertNameLength {
+		return hwErrors.NewValidationError(fmt.Sprintf("证书名称长度必须为 %d-%d 个字符", minCertNameLength, maxCertNameLength))
+	}
+
+	live, err := c.GetDomainConfig(domain)
+	if err != nil {
+		return err
+	}
+
+	status := "on"
+	source := int32(0) // 0 表示自有证书
+	certificate := string(certPEM)
+	privateKey := string(keyPEM)
+	https := &model.HttpPutBody{
+		HttpsStatus:       &status,
+		CertificateSource: &source,
+		CertificateName:   &certName,
+		CertificateValue:  &certificate,
+		PrivateKey:        &privateKey,
+	}
+	if current, ok := live.Configs["https"].(map[string]interface{}); ok {
+		https.Http2Status = stringField(current, "http2_status")
+		https.TlsVersion = stringField(current, "tls_version")
+		https.OcspStaplingStatus = stringField(current, "ocsp_stapling_status")
+	}
+
+	logx.Debugf("设置加速域名 %s 的证书: %s", domain, certName)
+	enterpriseProjectID := c.enterpriseProjectID()
+	_, err = c.cdnClient.UpdateDomainFullConfig(&model.UpdateDomainFullConfigRequest{
+		DomainName:          domain,
+		EnterpriseProjectId: &enterpriseProjectID,
+		Body:                &model.ModifyDomainConfigRequestBody{Configs: &model.Configs{Https: https}},
+	})
+	if err != nil {
+		logx.Debugf("设置加速域名证书失败: %v", err)
+		return hwErrors.FromSDKError(err)
+	}
+
+	logx.Infof("加速域名 %s 证书设置成功", domain)
+	return nil
+}
+
+// ListCertificates 查询加速域名的 HTTPS 证书，自动翻页获取全部
+func (c *Client) ListCertificates(opts CertificateListOptions) ([]DomainCertificate, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	enterpriseProjectID := c.enterpriseProjectID()
+	size := int32(certificateListPageSize)
+	request := &model.ShowCertificatesHttpsInfoRequest{
+		EnterpriseProjectId: &enterpriseProjectID,
+		PageSize:            &size,
+	}
+	if opts.Domain != "" {
+		request.DomainName = &opts.Domain
+	}
+
+	certs := []DomainCertificate{}
+	fetched := 0
+	for page := int32(1); page <= maxTaskDetailsPageNumber; page++ {
+		pageNumber := page
+		request.PageNumber = &pageNumber
+
+		response, err := c.cdnClient.ShowCertificatesHttpsInfo(request)
+		if err != nil {
+			logx.Debugf("查询加速域名证书失败: %v", err)
+			return nil, hwErrors.FromSDKError(err)
+		}
+
+		details := []model.HttpsDetail{}
+		if response.Https != nil {
+			details = *response.Https
+		}
+		fetched += len(details)
+		for i := range details {
+			cert, expiration := convertToDomainCertificate(&details[i], now)
+			if opts.ExpiringWithin > 0 && (expiration.IsZero() || expiration.After(now.Add(opts.ExpiringWithin))) {
+				continue
+			}
+			certs = append(certs, cert)
+		}
+
+		logx.Debugf("证书第 %d 页返回 %d 个，共 %d 个", page, len(details), getIntValue(response.Total))
+		if len(details) < certificateListPageSize || fetched >= getIntValue(response.Total) {
+			break
+		}
+	}
+	return certs, nil
+}
+
+// convertToDomainCertificate 转换证书信息，同时返回过期时间，未配置证书时为零值
+func convertToDomainCertificate(detail *model.HttpsDetail, now time.Time) (DomainCertificate, time.Time) {
+	cert := DomainCertificate{
+		Domain:   getStringValue(detail.DomainName),
+		CertName: getStringValue(detail.CertName),
+		HTTPS:    getIntValue(detail.HttpsStatus) != 0,
+	}
+
+	var expiration time.Time
+	if detail.ExpirationTime != nil && *detail.ExpirationTime > 0 {
+		expiration = time.UnixMilli(*detail.ExpirationTime)
+		cert.ExpiresAt = formatMillis(*detail.ExpirationTime)
+		cert.DaysLeft = daysUntil(expiration, now)
+	}
+	return cert, expiration
+}
+
+// stringField 读取通用配置中的字符串字段，不存在时返回 nil
+func stringField(m map[string]interface{}, key string) *string {
+	if value, ok := m[key].(string); ok && value != "" {
+		return &value
+	}
+	return nil
+}
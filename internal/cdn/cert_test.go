@@ -0,0 +1,189 @@
+package cdn
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCert 测试用证书及其私钥
+type testCert struct {
+	cert   *x509.Certificate
+	key    *ecdsa.PrivateKey
+	pem    []byte
+	keyPEM []byte
+}
+
+// newTestCert 生成测试证书，parent 为 nil 时生成自签名 CA 证书
+func newTestCert(t *testing.T, cn string, dnsNames []string, notBefore, notAfter time.Time, parent *testCert) *testCert {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("生成私钥失败: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: cn},
+		DNSNames:              dnsNames,
+		NotBefore:             notBefore,
+		NotAfter:              notAfter,
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.cert, parent.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatalf("生成证书失败: %v", err)
+	}
+	cert, _ := x509.ParseCertificate(der)
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("编码私钥失败: %v", err)
+	}
+	return &testCert{
+		cert:   cert,
+		key:    key,
+		pem:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		keyPEM: pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER}),
+	}
+}
+
+func TestValidateCertificate(t *testing.T) {
+	now := time.Now()
+	ca := newTestCert(t, "Test CA", nil, now.Add(-time.Hour), now.Add(365*24*time.Hour), nil)
+	leaf := newTestCert(t, "cdn.example.com", []string{"cdn.example.com", "*.example.com"},
+		now.Add(-time.Hour), now.Add(90*24*time.Hour), ca)
+	other := newTestCert(t, "other", []string{"other.example.org"}, now.Add(-time.Hour), now.Add(time.Hour), ca)
+	expired := newTestCert(t, "cdn.example.com", []string{"cdn.example.com"},
+		now.Add(-48*time.Hour), now.Add(-24*time.Hour), ca)
+
+	chain := append(append([]byte{}, leaf.pem...), ca.pem...)
+	info, err := ValidateCertificate(chain, leaf.keyPEM, "cdn.example.com", now)
+	if err != nil {
+		t.Fatalf("期望校验通过，实际为 %v", err)
+	}
+	if info.ChainLength != 2 || info.Subject != "cdn.example.com" || info.DaysLeft != 89 {
+		t.Errorf("证书信息错误: %+v", info)
+	}
+
+	tests := []struct {
+		name    string
+		cert    []byte
+		key     []byte
+		domain  string
+		wantErr string
+	}{
+		{"泛域名覆盖子域名", leaf.pem, leaf.keyPEM, "img.example.com", ""},
+		{"泛域名加速域名", leaf.pem, leaf.keyPEM, "*.example.com", ""},
+		{"证书链顺序错误", append(append([]byte{}, ca.pem...), leaf.pem...), ca.keyPEM, "cdn.example.com", "证书链顺序错误"},
+		{"私钥不匹配", leaf.pem, other.keyPEM, "cdn.example.com", "私钥与证书不匹配"},
+		{"域名未覆盖", leaf.pem, leaf.keyPEM, "cdn.example.org", "证书不包含域名"},
+		{"证书已过期", expired.pem, expired.keyPEM, "cdn.example.com", "证书已过期"},
+		{"不是证书", []byte("not a certificate"), leaf.keyPEM, "cdn.example.com", "没有 PEM 格式的证书"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ValidateCertificate(tt.cert, tt.key, tt.domain, now)
+			if tt.wantErr == "" {
+				if err != nil {
+					t.Errorf("期望校验通过，实际为 %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("期望错误包含 %q，实际为 %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestDefaultCertName(t *testing.T) {
+	info := &CertificateInfo{NotAfter: "2026-12-31 08:00:00"}
+	if got := DefaultCertName("*.example.com", info); got != "wildcard.example.com-20261231" {
+		t.Errorf("默认证书名称错误: %s", got)
+	}
+}
+
+func TestSetCertificatePreservesHTTPSOptions(t *testing.T) {
+	var body struct {
+		Configs struct {
+			Https map[string]interface{} `json:"https"`
+		} `json:"configs"`
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("解析请求失败: %v", err)
+			}
+			writeJSON(t, w, map[string]interface{}{})
+			return
+		}
+		writeJSON(t, w, map[string]interface{}{"configs": map[string]interface{}{
+			"https": map[string]interface{}{"https_status": "on", "http2_status": "on", "tls_version": "TLSv1.2,TLSv1.3"},
+		}})
+	})
+
+	if err := client.SetCertificate("cdn.example.com", "example-cert", []byte("CERT"), []byte("KEY")); err != nil {
+		t.Fatalf("设置证书失败: %v", err)
+	}
+
+	https := body.Configs.Https
+	if https["certificate_value"] != "CERT" || https["private_key"] != "KEY" || https["certificate_name"] != "example-cert" {
+		t.Errorf("证书内容错误: %v", https)
+	}
+	if https["http2_status"] != "on" || https["tls_version"] != "TLSv1.2,TLSv1.3" {
+		t.Errorf("应保留线上的 HTTPS 选项: %v", https)
+	}
+
+	if err := client.SetCertificate("cdn.example.com", "ab", []byte("CERT"), []byte("KEY")); err == nil {
+		t.Error("证书名称过短时应返回错误")
+	}
+}
+
+func TestListCertificatesExpiringWithin(t *testing.T) {
+	now := time.Now()
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]interface{}{
+			"total": 3,
+			"https": []map[string]interface{}{
+				{"domain_name": "a.example.com", "cert_name": "a", "https_status": 1,
+					"expiration_time": now.Add(10 * 24 * time.Hour).UnixMilli()},
+				{"domain_name": "b.example.com", "cert_name": "b", "https_status": 1,
+					"expiration_time": now.Add(100 * 24 * time.Hour).UnixMilli()},
+				{"domain_name": "c.example.com", "https_status": 0},
+			},
+		})
+	})
+
+	all, err := client.ListCertificates(CertificateListOptions{Now: now})
+	if err != nil {
+		t.Fatalf("查询失败: %v", err)
+	}
+	if len(all) != 3 || !all[0].HTTPS || all[2].HTTPS || all[2].ExpiresAt != "" {
+		t.Errorf("证书列表错误: %+v", all)
+	}
+
+	expiring, err := client.ListCertificates(CertificateListOptions{Now: now, ExpiringWithin: 30 * 24 * time.Hour})
+	if err != nil {
+		t.Fatalf("查询失败: %v", err)
+	}
+	if len(expiring) != 1 || expiring[0].Domain != "a.example.com" || expiring[0].DaysLeft != 9 {
+		t.Errorf("期望只返回 a.example.com，实际为 %+v", expiring)
+	}
+}
@@ -1,10 +1,13 @@
 package cdn
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"time"
 
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/auth/global"
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/config"
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/region"
 	cdn "github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2"
 	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2/model"
@@ -17,6 +20,7 @@ import (
 type Client struct {
 	cdnClient *cdn.CdnClient
 	region    string
+	creds     *auth.Credentials
 }
 
 // Task 任务信息
@@ -27,6 +31,27 @@ type Task struct {
 	CreatedAt   string `json:"created_at" table:"创建时间"`
 	CompletedAt string `json:"completed_at,omitempty" table:"完成时间"`
 	Progress    int    `json:"progress" table:"进度(%)"`
+	FileType    string `json:"file_type,omitempty" table:"文件类型"`
+	Total       int    `json:"total" table:"URL总数"`
+	Succeed     int    `json:"succeed" table:"成功"`
+	Failed      int    `json:"failed" table:"失败"`
+}
+
+// 任务状态（转换后的展示值）
+const (
+	TaskStatusProcessing = "进行中"
+	TaskStatusDone       = "已完成"
+	TaskStatusFailed     = "失败"
+)
+
+// IsFinished 判断任务是否已结束（完成或失败）
+func (t *Task) IsFinished() bool {
+	return t.Status == TaskStatusDone || t.Status == TaskStatusFailed
+}
+
+// IsFailed 判断任务是否失败
+func (t *Task) IsFailed() bool {
+	return t.Status == TaskStatusFailed
 }
 
 // RefreshResult 刷新结果
@@ -47,10 +72,10 @@ type PreloadResult struct {
 	CreatedAt time.Time `json:"created_at" table:"创建时间"`
 }
 
-// NewClient 创建新的 CDN 客户端
-func NewClient() (*Client, error) {
+// NewClient 使用已解析的会话创建新的 CDN 客户端
+func NewClient(session *auth.Session) (*Client, error) {
 	// 获取认证信息
-	creds, err := auth.GetCredentials()
+	creds, err := session.Credentials()
 	if err != nil {
 		return nil, hwErrors.NewAuthError(fmt.Sprintf("获取认证信息失败: %v", err))
 	}
@@ -64,8 +89,10 @@ func NewClient() (*Client, error) {
 		domainIDPreview = domainIDPreview[:8] + "..."
 	}
 
-	logx.Debugf("CDN 客户端认证信息 - Region: %s, AccessKey: %s, DomainID: %s, ProjectID: %s, EnterpriseProjectID: %s",
-		creds.Region, accessKeyPreview, domainIDPreview, creds.ProjectID, creds.EnterpriseProjectID) // 验证必需的认证信息
+	logx.Debug("CDN 客户端认证信息", "region", creds.Region, "access_key", accessKeyPreview, "domain_id", domainIDPreview,
+		"project_id", creds.ProjectID, "enterprise_project_id", creds.EnterpriseProjectID)
+
+	// 验证必需的认证信息
 	if creds.AccessKeyID == "" || creds.SecretAccessKey == "" {
 		return nil, hwErrors.NewAuthError("Access Key ID 和 Secret Access Key 不能为空")
 	}
@@ -84,58 +111,80 @@ func NewClient() (*Client, error) {
 		credentialsBuilder = credentialsBuilder.WithDomainId(creds.DomainID)
 	}
 
+	// 未配置 Domain ID 时 SDK 会通过 IAM 自动获取，需要同样遵循 IAM 端点覆盖
+	if iamEndpoint := session.Endpoint(auth.ServiceIAM); iamEndpoint != "" {
+		credentialsBuilder = credentialsBuilder.WithIamEndpointOverride(iamEndpoint)
+	}
+
 	authCredentials, err := credentialsBuilder.SafeBuild()
 	if err != nil {
 		return nil, hwErrors.NewAuthError(fmt.Sprintf("创建认证信息失败: %v", err))
 	}
 
-	// 获取区域对象 - 回退到原始方法但增加调试信息
-	regionObj, err := getRegion(creds.Region)
+	// 获取区域对象，配置了端点覆盖时使用覆盖的端点
+	regionObj, err := resolveRegion(creds.Region, session.Endpoint(auth.ServiceCDN))
 	if err != nil {
 		return nil, hwErrors.NewValidationError(fmt.Sprintf("不支持的区域: %s", creds.Region))
 	}
 
-	// 创建客户端配置 - 使用默认配置测试
-	logx.Debugf("区域信息 - ID: %s", regionObj.Id)
-	logx.Debugf("准备创建CDN客户端，使用默认HTTP配置...")
+	logx.Debug("准备创建 CDN 客户端", "region", regionObj.Id, "endpoints", regionObj.Endpoints)
 
-	// 创建 CDN 客户端 - 先尝试不设置自定义HTTP配置
-	hcClient, err := cdn.CdnClientBuilder().
+	builder := cdn.CdnClientBuilder().
 		WithRegion(regionObj).
-		WithCredential(authCredentials).
-		SafeBuild()
+		WithCredential(authCredentials)
+	// 开启 --debug-http 时记录 SDK 发出的每个请求，与 SDK 默认一样不使用代理
+	if logx.HTTPDebug() {
+		builder = builder.WithHttpConfig(config.DefaultHttpConfig().
+			WithHttpRoundTripper(logx.HTTPTransport(&http.Transport{})))
+	}
+	hcClient, err := builder.SafeBuild()
 	if err != nil {
 		return nil, hwErrors.NewServerError(fmt.Sprintf("创建CDN客户端失败: %v", err))
 	}
 
 	cdnClient := cdn.NewCdnClient(hcClient)
 
-	logx.Debugf("CDN 客户端创建成功，区域: %s", creds.Region)
+	logx.Debug("CDN 客户端创建成功", "region", creds.Region)
 
 	return &Client{
 		cdnClient: cdnClient,
 		region:    creds.Region,
+		creds:     creds,
 	}, nil
 }
 
 // RefreshCache 刷新 CDN 缓存
-func (c *Client) RefreshCache(urls []string, refreshType string) (string, error) {
-	logx.Debugf("开始刷新 CDN 缓存，类型: %s, URLs: %v", refreshType, urls)
+// URL 数量超过单次请求上限时自动拆分为多个批次并发提交，提交前检查当日剩余配额
+func (c *Client) RefreshCache(ctx context.Context, urls []string, refreshType string, opts BatchOptions) (*BatchResult, error) {
+	logx.Debugf("开始刷新 CDN 缓存，类型: %s, URL 数量: %d", refreshType, len(urls))
 
-	// 构建请求 - 完全按照官方示例
-	request := &model.CreateRefreshTasksRequest{}
-
-	// 设置刷新类型
+	// 设置刷新类型，目录刷新的单次上限和配额与文件刷新不同
 	var typeEnum model.RefreshTaskRequestBodyType
+	var limit int
+	var quotaType string
 	switch refreshType {
 	case "url", "file":
 		typeEnum = model.GetRefreshTaskRequestBodyTypeEnum().FILE
+		limit = MaxRefreshURLsPerRequest
+		quotaType = QuotaTypeURLRefresh
 	case "directory", "dir":
 		typeEnum = model.GetRefreshTaskRequestBodyTypeEnum().DIRECTORY
+		limit = MaxRefreshDirectoriesPerRequest
+		quotaType = QuotaTypeDirRefresh
 	default:
-		return "", hwErrors.NewValidationError(fmt.Sprintf("不支持的刷新类型: %s，支持的类型: url, directory", refreshType))
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("不支持的刷新类型: %s，支持的类型: url, directory", refreshType))
 	}
 
+	return c.runBatches(ctx, "refresh", quotaType, urls, limit, opts, func(batch []string) (string, error) {
+		return c.createRefreshTask(batch, typeEnum)
+	})
+}
+
+// createRefreshTask 提交单个刷新任务
+func (c *Client) createRefreshTask(urls []string, typeEnum model.RefreshTaskRequestBodyType) (string, error) {
+	// 构建请求 - 完全按照官方示例
+	request := &model.CreateRefreshTasksRequest{}
+
 	// 构建刷新任务体 - 按照官方示例
 	refreshTaskBody := &model.RefreshTaskRequestBody{
 		Type: &typeEnum,
@@ -147,55 +196,40 @@ func (c *Client) RefreshCache(urls []string, refreshType string) (string, error)
 		RefreshTask: refreshTaskBody,
 	}
 
-	// 获取统一的项目ID（懒加载模式）
-	projectID, err := auth.GetUnifiedProjectID()
-	if err != nil {
-		logx.Debugf("获取项目ID失败，将使用默认配置: %v", err)
-		projectID = "0" // 使用默认值
-	}
-
-	// 设置企业项目ID（如果不是默认值）
-	if projectID != "" && projectID != "0" {
-		logx.Debugf("使用统一项目ID: %s", projectID)
-		request.EnterpriseProjectId = &projectID
-	} else {
-		logx.Debugf("使用默认企业项目ID: 0")
-	} // 打印详细的请求信息
-	logx.Debugf("准备发送CDN刷新请求")
-	logx.Debugf("请求URL将会是: https://cdn.myhuaweicloud.com/v1.0/cdn/content/refresh-tasks")
-	logx.Debugf("刷新类型: %v", *refreshTaskBody.Type)
-	logx.Debugf("URL列表: %v", refreshTaskBody.Urls)
+	// 设置企业项目ID，与预热保持一致使用会话中的配置
+	enterpriseProjectID := c.enterpriseProjectID()
+	logx.Debugf("使用企业项目ID: %s", enterpriseProjectID)
+	request.EnterpriseProjectId = &enterpriseProjectID
 
-	// 发送请求
-	logx.Debugf("开始发送请求到华为云CDN服务...")
+	// 实际发送的请求可以通过 --debug-http 查看
+	logx.Debug("提交刷新任务", "type", typeEnum.Value(), "url_count", len(urls))
 	response, err := c.cdnClient.CreateRefreshTasks(request)
 	if err != nil {
-		logx.Errorf("刷新 CDN 缓存失败: %v", err)
-		logx.Errorf("错误类型: %T", err)
-		return "", hwErrors.ParseHuaweiCloudError(500, err.Error())
+		hwErr := hwErrors.FromSDKError(err)
+		logx.Debug("刷新 CDN 缓存失败", logx.KeyError, err, logx.KeyRequestID, hwErr.RequestID)
+		return "", hwErr
 	}
-	logx.Debugf("请求发送成功，收到响应")
 
 	if response.RefreshTask == nil {
 		return "", hwErrors.NewServerError("刷新任务响应为空")
 	}
 
 	taskID := *response.RefreshTask
-	logx.Infof("CDN 缓存刷新任务创建成功，任务ID: %s", taskID)
+	logx.Info("CDN 缓存刷新任务创建成功", "task_id", taskID, "url_count", len(urls))
 
 	return taskID, nil
 }
 
 // PreloadCache 预热 CDN 缓存
-func (c *Client) PreloadCache(urls []string) (string, error) {
-	logx.Debugf("开始预热 CDN 缓存，URLs: %v", urls)
+// URL 数量超过单次请求上限时自动拆分为多个批次并发提交，提交前检查当日剩余配额
+func (c *Client) PreloadCache(ctx context.Context, urls []string, opts BatchOptions) (*BatchResult, error) {
+	logx.Debugf("开始预热 CDN 缓存，URL 数量: %d", len(urls))
 
-	// 获取认证信息以获取企业项目ID
-	creds, err := auth.GetCredentials()
-	if err != nil {
-		return "", hwErrors.NewAuthError(fmt.Sprintf("获取认证信息失败: %v", err))
-	}
+	return c.runBatches(ctx, "preload", QuotaTypeURLPreload, urls, MaxPreloadURLsPerRequest, opts, c.createPreloadTask)
+}
 
+// createPreloadTask 提交单个预热任务
+func (c *Client) createPreloadTask(urls []string) (string, error) {
 	// 构建预热请求体
 	preheatingTaskBody := &model.PreheatingTaskRequestBody{
 		Urls: urls,
@@ -207,10 +241,7 @@ func (c *Client) PreloadCache(urls []string) (string, error) {
 	}
 
 	// 设置企业项目ID
-	enterpriseProjectId := creds.EnterpriseProjectID
-	if enterpriseProjectId == "" {
-		enterpriseProjectId = "0" // 默认企业项目
-	}
+	enterpriseProjectId := c.enterpriseProjectID()
 
 	logx.Debugf("使用企业项目ID: %s", enterpriseProjectId)
 
@@ -222,8 +253,9 @@ func (c *Client) PreloadCache(urls []string) (string, error) {
 	// 发送请求
 	response, err := c.cdnClient.CreatePreheatingTasks(request)
 	if err != nil {
-		logx.Errorf("预热 CDN 缓存失败: %v", err)
-		return "", hwErrors.ParseHuaweiCloudError(500, err.Error())
+		hwErr := hwErrors.FromSDKError(err)
+		logx.Debug("预热 CDN 缓存失败", logx.KeyError, err, logx.KeyRequestID, hwErr.RequestID)
+		return "", hwErr
 	}
 
 	if response.PreheatingTask == nil {
@@ -231,44 +263,35 @@ func (c *Client) PreloadCache(urls []string) (string, error) {
 	}
 
 	taskID := *response.PreheatingTask
-	logx.Infof("CDN 缓存预热任务创建成功，任务ID: %s", taskID)
+	logx.Info("CDN 缓存预热任务创建成功", "task_id", taskID, "url_count", len(urls))
 
 	return taskID, nil
 }
 
-// GetTaskStatus 查询任务状态
+// GetTaskStatus 按任务ID查询任务状态
+// 使用任务详情接口直接定位任务，不受历史任务列表分页的限制，只请求一个 URL 以获取汇总信息
 func (c *Client) GetTaskStatus(taskID string) (*Task, error) {
 	logx.Debugf("查询任务状态，任务ID: %s", taskID)
 
-	// 构建查询请求
-	request := &model.ShowHistoryTasksRequest{}
-
-	// 设置查询范围（最近7天）
-	endTime := time.Now().Unix() * 1000
-	startTime := time.Now().AddDate(0, 0, -7).Unix() * 1000
-	request.StartDate = &startTime
-	request.EndDate = &endTime
-
-	// 发送请求
-	response, err := c.cdnClient.ShowHistoryTasks(request)
+	enterpriseProjectID := c.enterpriseProjectID()
+	pageSize, pageNumber := int32(1), int32(1)
+	response, err := c.cdnClient.ShowHistoryTaskDetails(&model.ShowHistoryTaskDetailsRequest{
+		EnterpriseProjectId: &enterpriseProjectID,
+		HistoryTasksId:      taskID,
+		PageSize:            &pageSize,
+		PageNumber:          &pageNumber,
+	})
 	if err != nil {
-		logx.Errorf("查询任务状态失败: %v", err)
-		return nil, hwErrors.ParseHuaweiCloudError(500, err.Error())
+		hwErr := hwErrors.FromSDKError(err)
+		logx.Debug("查询任务状态失败", "task_id", taskID, logx.KeyError, err, logx.KeyRequestID, hwErr.RequestID)
+		return nil, hwErr
 	}
 
-	if response.Tasks == nil || len(*response.Tasks) == 0 {
+	details := convertToTaskDetails(response)
+	if details.ID == "" {
 		return nil, hwErrors.NewNotFoundError(fmt.Sprintf("任务 %s", taskID))
 	}
-
-	// 查找指定的任务
-	tasks := *response.Tasks
-	for _, task := range tasks {
-		if task.Id != nil && *task.Id == taskID {
-			return convertToTask(&task), nil
-		}
-	}
-
-	return nil, hwErrors.NewNotFoundError(fmt.Sprintf("任务 %s", taskID))
+	return &details.Task, nil
 }
 
 // convertToTask 转换华为云任务对象为内部任务对象
@@ -291,38 +314,66 @@ func convertToTask(hwTask *model.TasksObject) *Task {
 
 	// 转换任务状态
 	if hwTask.Status != nil {
-		switch *hwTask.Status {
-		case "task_inprocess":
-			task.Status = "进行中"
-		case "task_done":
-			task.Status = "已完成"
-		case "task_failed":
-			task.Status = "失败"
-		default:
-			task.Status = *hwTask.Status
-		}
+		task.Status = convertTaskStatus(*hwTask.Status)
 	}
 
 	// 转换时间
 	if hwTask.CreateTime != nil {
-		task.CreatedAt = time.Unix(*hwTask.CreateTime/1000, 0).Format("2006-01-02 15:04:05")
+		task.CreatedAt = formatMillis(*hwTask.CreateTime)
 	}
 
+	if hwTask.FileType != nil {
+		task.FileType = hwTask.FileType.Value()
+	}
+
+	// URL 统计
+	task.Total = getIntValue(hwTask.Total)
+	task.Succeed = getIntValue(hwTask.Succeed)
+	task.Failed = getIntValue(hwTask.Failed)
+
 	// 计算进度
 	if hwTask.Processing != nil && hwTask.Total != nil {
-		total := *hwTask.Total
-		succeed := getIntValue(hwTask.Succeed)
-		failed := getIntValue(hwTask.Failed)
-
-		if total > 0 {
-			completed := succeed + failed
-			task.Progress = int(float64(completed) / float64(total) * 100)
-		}
+		task.Progress = calculateProgress(*hwTask.Total, task.Succeed, task.Failed)
 	}
 
 	return task
 }
 
+// convertTaskStatus 转换华为云任务状态为展示值
+func convertTaskStatus(status string) string {
+	switch status {
+	case "task_inprocess":
+		return TaskStatusProcessing
+	case "task_done":
+		return TaskStatusDone
+	case "task_failed":
+		return TaskStatusFailed
+	default:
+		return status
+	}
+}
+
+// calculateProgress 根据成功和失败数量计算任务进度百分比
+func calculateProgress(total int32, succeed, failed int) int {
+	if total <= 0 {
+		return 0
+	}
+	return int(float64(succeed+failed) / float64(total) * 100)
+}
+
+// formatMillis 将毫秒时间戳格式化为本地时间字符串
+func formatMillis(ms int64) string {
+	return time.Unix(ms/1000, 0).Format("2006-01-02 15:04:05")
+}
+
+// resolveRegion 获取区域对象，endpoint 非空时使用自定义端点（专属云、私有化部署或本地 mock 服务）
+func resolveRegion(regionName, endpoint string) (*region.Region, error) {
+	if endpoint != "" {
+		return region.NewRegion(regionName, endpoint), nil
+	}
+	return getRegion(regionName)
+}
+
 // getRegion 获取华为云区域对象
 func getRegion(regionName string) (*region.Region, error) {
 	// 华为云支持的区域映射
@@ -364,3 +415,11 @@ func getIntValue(ptr *int32) int {
 	}
 	return int(*ptr)
 }
+
+// getInt64Value 安全获取 int64 指针的值
+func getInt64Value(ptr *int64) int64 {
+	if ptr == nil {
+		return 0
+	}
+	return *ptr
+}
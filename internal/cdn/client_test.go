@@ -1,15 +1,39 @@
 package cdn
 
 import (
+	stderrors "errors"
+	"net/http"
 	"testing"
 	"time"
+
+	"github.com/ygqygq2/hwcctl/internal/auth"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
 )
 
 func TestNewClient(t *testing.T) {
-	// 测试创建客户端 - 由于需要真实的认证信息，这里只测试不panic
-	_, err := NewClient()
-	// 不管成功失败，只要不panic就算通过
-	t.Logf("NewClient返回错误: %v", err)
+	// 未初始化的会话应该返回认证错误
+	if _, err := NewClient(nil); err == nil {
+		t.Error("期望未初始化会话时返回错误")
+	}
+
+	// 使用会话中的凭证创建客户端，不会读取全局配置
+	session := auth.NewSessionFromConfig(&auth.Config{
+		AccessKey:           "test-ak",
+		SecretKey:           "test-sk",
+		Region:              "cn-north-1",
+		DomainID:            "test-domain-id", // 提供 DomainID 避免 SDK 联网自动获取
+		EnterpriseProjectID: "0",
+	})
+	client, err := NewClient(session)
+	if err != nil {
+		t.Fatalf("期望成功创建客户端，实际出错: %v", err)
+	}
+	if client.region != "cn-north-1" {
+		t.Errorf("期望区域为 cn-north-1，实际为 %s", client.region)
+	}
+	if client.creds.AccessKeyID != "test-ak" {
+		t.Errorf("期望客户端使用会话中的 AccessKey，实际为 %s", client.creds.AccessKeyID)
+	}
 }
 
 func TestGetStringValue(t *testing.T) {
@@ -59,6 +83,26 @@ func TestGetRegion(t *testing.T) {
 	}
 }
 
+func TestResolveRegionEndpointOverride(t *testing.T) {
+	// 配置了端点覆盖时，即使区域不在内置列表中也应使用自定义端点
+	regionObj, err := resolveRegion("private-region-1", "http://127.0.0.1:8080")
+	if err != nil {
+		t.Fatalf("期望成功解析自定义端点，实际出错: %v", err)
+	}
+	if len(regionObj.Endpoints) != 1 || regionObj.Endpoints[0] != "http://127.0.0.1:8080" {
+		t.Errorf("期望端点为 http://127.0.0.1:8080，实际为 %v", regionObj.Endpoints)
+	}
+
+	// 未覆盖时回退到内置区域映射
+	regionObj, err = resolveRegion("ap-southeast-1", "")
+	if err != nil {
+		t.Fatalf("期望成功解析内置区域，实际出错: %v", err)
+	}
+	if regionObj.Endpoints[0] != "https://cdn.ap-southeast-1.myhuaweicloud.com" {
+		t.Errorf("内置区域端点错误: %v", regionObj.Endpoints)
+	}
+}
+
 func TestTask_Struct(t *testing.T) {
 	// 测试Task结构体能正常创建和使用
 	task := Task{
@@ -167,9 +211,9 @@ func isValidURL(url string) bool {
 func TestCDNClientCreation(t *testing.T) {
 	// 测试CDN客户端创建的业务逻辑和错误处理
 	t.Run("客户端创建失败时的错误处理", func(t *testing.T) {
-		// 在测试环境中，由于没有有效的华为云凭证，客户端创建应该失败
+		// 会话中缺少凭证时客户端创建应该失败
 		// 这个测试验证错误处理逻辑是否正确
-		_, err := NewClient()
+		_, err := NewClient(auth.NewSessionFromConfig(&auth.Config{Region: "cn-north-1"}))
 		if err != nil {
 			// 验证错误类型和消息的合理性
 			t.Logf("期望的客户端创建错误: %v", err)
@@ -347,3 +391,24 @@ func TestResultStructsDataIntegrity(t *testing.T) {
 		}
 	})
 }
+
+func TestClientConvertsServiceResponseError(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error_code": "APIGW.0301", "error_msg": "Incorrect IAM authentication information"}`))
+	})
+
+	_, err := client.GetTaskStatus("task-1")
+	var hwErr *hwErrors.HuaweiCloudError
+	if !stderrors.As(err, &hwErr) {
+		t.Fatalf("期望返回 HuaweiCloudError，实际为 %T: %v", err, err)
+	}
+	if hwErr.Type != hwErrors.ErrorTypeAuth || hwErr.Code != "APIGW.0301" || hwErr.StatusCode != http.StatusUnauthorized {
+		t.Errorf("错误分类不正确: %+v", hwErr)
+	}
+	if hwErr.RequestID != "req-123" || hwErr.Hint == "" {
+		t.Errorf("应包含请求 ID 和处理建议: %+v", hwErr)
+	}
+}
@@ -0,0 +1,285 @@
+package cdn
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2/model"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// ConfigSections 支持通过 apply 更新的配置项，名称与 CDN 接口中的字段一致
+var ConfigSections = []string{
+	"sources",               // 源站
+	"origin_protocol",       // 回源协议
+	"origin_request_header", // 回源请求头
+	"http_response_header",  // HTTP 响应头
+	"cache_rules",           // 缓存规则
+	"browser_cache_rules",   // 浏览器缓存规则
+	"referer",               // Referer 防盗链
+	"ip_filter",             // IP 黑白名单
+	"user_agent_filter",     // User-Agent 黑白名单
+	"compress",              // 智能压缩
+	"force_redirect",        // 强制跳转（如 HTTP 跳转 HTTPS）
+	"hsts",                  // HSTS
+}
+
+// 配置变更类型
+const (
+	ConfigActionAdd    = "add"
+	ConfigActionUpdate = "update"
+)
+
+// DomainConfig 加速域名配置，Configs 的键为配置项名称
+type DomainConfig struct {
+	Domain  string                 `json:"domain" yaml:"domain"`
+	Configs map[string]interface{} `json:"configs" yaml:"configs"`
+}
+
+// ConfigChange 单个配置项的变更
+type ConfigChange struct {
+	Section string      `json:"section" yaml:"section"`
+	Action  string      `json:"action" yaml:"action"`
+	Current interface{} `json:"current,omitempty" yaml:"current,omitempty"`
+	Desired interface{} `json:"desired" yaml:"desired"`
+}
+
+// ConfigDiff 期望配置与线上配置的差异
+type ConfigDiff struct {
+	Domain  string         `json:"domain" yaml:"domain"`
+	Changes []ConfigChange `json:"changes" yaml:"changes"`
+	// Ignored 期望配置中不支持通过 apply 更新的配置项
+	Ignored []string `json:"ignored,omitempty" yaml:"ignored,omitempty"`
+}
+
+// HasChanges 判断是否存在需要更新的配置项
+func (d *ConfigDiff) HasChanges() bool {
+	return len(d.Changes) > 0
+}
+
+// GetDomainConfig 查询加速域名的完整配置
+func (c *Client) GetDomainConfig(name string) (*DomainConfig, error) {
+	logx.Debugf("查询加速域名配置: %s", name)
+
+	enterpriseProjectID := c.enterpriseProjectID()
+	response, err := c.cdnClient.ShowDomainFullConfig(&model.ShowDomainFullConfigRequest{
+		DomainName:          name,
+		EnterpriseProjectId: &enterpriseProjectID,
+	})
+	if err != nil {
+		logx.Debugf("查询加速域名配置失败: %v", err)
+		return nil, hwErrors.FromSDKError(err)
+	}
+	if response.Configs == nil {
+		return nil, hwErrors.NewNotFoundError(fmt.Sprintf("加速域名 %s 的配置", name))
+	}
+
+	configs, err := toGenericMap(response.Configs)
+	if err != nil {
+		return nil, hwErrors.NewServerError(fmt.Sprintf("解析加速域名配置失败: %v", err))
+	}
+	return &DomainConfig{Domain: name, Configs: configs}, nil
+}
+
+// ParseDomainConfig 解析 YAML 或 JSON 格式的期望配置
+func ParseDomainConfig(data []byte) (*DomainConfig, error) {
+	config := &DomainConfig{}
+	if err := yaml.Unmarshal(data, config); err != nil {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("解析域名配置文件失败: %v", err))
+	}
+	if err := validateDomainConfig(config); err != nil {
+		return nil, err
+	}
+	return config, nil
+}
+
+// validateDomainConfig 校验期望配置必须包含域名和至少一个配置项
+func validateDomainConfig(config *DomainConfig) error {
+	if config.Domain == "" {
+		return hwErrors.NewValidationError("域名配置缺少 domain 字段")
+	}
+	if len(config.Configs) == 0 {
+		return hwErrors.NewValidationError(fmt.Sprintf("域名 %s 的配置缺少 configs 字段", config.Domain))
+	}
+	return nil
+}
+
+// DiffDomainConfig 比较期望配置与线上配置，只返回期望配置中出现且与线上不同的配置项
+// 线上配置包含接口填充的默认值和额外字段，期望配置只需是线上配置的子集即视为一致
+func DiffDomainConfig(live, desired *DomainConfig) (*ConfigDiff, error) {
+	diff := &ConfigDiff{Domain: desired.Domain, Changes: []ConfigChange{}}
+
+	desiredConfigs, err := toGenericMap(desired.Configs)
+	if err != nil {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("解析期望配置失败: %v", err))
+	}
+	liveConfigs := map[string]interface{}{}
+	if live != nil && live.Configs != nil {
+		if liveConfigs, err = toGenericMap(live.Configs); err != nil {
+			return nil, hwErrors.NewServerError(fmt.Sprintf("解析线上配置失败: %v", err))
+		}
+	}
+
+	sections := make([]string, 0, len(desiredConfigs))
+	for section := range desiredConfigs {
+		sections = append(sections, section)
+	}
+	sort.Strings(sections)
+
+	for _, section := range sections {
+		if !utils.StringSliceContains(ConfigSections, section) {
+			diff.Ignored = append(diff.Ignored, section)
+			continue
+		}
+
+		want := desiredConfigs[section]
+		current, exists := liveConfigs[section]
+		if exists && configContains(current, want) {
+			continue
+		}
+
+		change := ConfigChange{Section: section, Action: ConfigActionUpdate, Current: current, Desired: want}
+		if !exists {
+			change.Action = ConfigActionAdd
+		}
+		diff.Changes = append(diff.Changes, change)
+	}
+
+	return diff, nil
+}
+
+// ApplyDomainConfig 只更新差异中的配置项
+// 接口按配置项整体覆盖，提交前将期望配置合并到线上配置中，保留期望配置未指定的字段
+func (c *Client) ApplyDomainConfig(diff *ConfigDiff) error {
+	if !diff.HasChanges() {
+		return nil
+	}
+
+	changed := make(map[string]interface{}, len(diff.Changes))
+	for _, change := range diff.Changes {
+		changed[change.Section] = mergeConfig(change.Current, change.Desired)
+	}
+
+	data, err := json.Marshal(changed)
+	if err != nil {
+		return hwErrors.NewValidationError(fmt.Sprintf("构建配置请求失败: %v", err))
+	}
+	configs := &model.Configs{}
+	if err := json.Unmarshal(data, configs); err != nil {
+		return hwErrors.NewValidationError(fmt.Sprintf("配置格式无效: %v", err))
+	}
+
+	logx.Debugf("更新加速域名 %s 的配置项: %v", diff.Domain, sortedKeys(changed))
+	enterpriseProjectID := c.enterpriseProjectID()
+	_, err = c.cdnClient.UpdateDomainFullConfig(&model.UpdateDomainFullConfigRequest{
+		DomainName:          diff.Domain,
+		EnterpriseProjectId: &enterpriseProjectID,
+		Body:                &model.ModifyDomainConfigRequestBody{Configs: configs},
+	})
+	if err != nil {
+		logx.Debugf("更新加速域名配置失败: %v", err)
+		return hwErrors.FromSDKError(err)
+	}
+
+	logx.Infof("加速域名 %s 配置更新成功，共 %d 项", diff.Domain, len(diff.Changes))
+	return nil
+}
+
+// configContains 判断期望配置是否为线上配置的子集
+// 对象只比较期望配置中出现的字段，数组要求长度相同并逐个元素比较，其余值要求相等
+func configContains(live, want interface{}) bool {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return false
+		}
+		for key, value := range w {
+			current, exists := l[key]
+			if !exists {
+				if value == nil {
+					continue
+				}
+				return false
+			}
+			if !configContains(current, value) {
+				return false
+			}
+		}
+		return true
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(w) {
+			return false
+		}
+		for i := range w {
+			if !configContains(l[i], w[i]) {
+				return false
+			}
+		}
+		return true
+	default:
+		return reflect.DeepEqual(live, want)
+	}
+}
+
+// mergeConfig 将期望配置深度合并到线上配置，与 configContains 的比较规则对应
+// 对象逐个字段合并，期望配置中的值优先；数组长度相同时逐个元素合并，否则使用期望配置
+func mergeConfig(live, want interface{}) interface{} {
+	switch w := want.(type) {
+	case map[string]interface{}:
+		l, ok := live.(map[string]interface{})
+		if !ok {
+			return want
+		}
+		merged := make(map[string]interface{}, len(l)+len(w))
+		for key, value := range l {
+			merged[key] = value
+		}
+		for key, value := range w {
+			merged[key] = mergeConfig(l[key], value)
+		}
+		return merged
+	case []interface{}:
+		l, ok := live.([]interface{})
+		if !ok || len(l) != len(w) {
+			return want
+		}
+		merged := make([]interface{}, len(w))
+		for i := range w {
+			merged[i] = mergeConfig(l[i], w[i])
+		}
+		return merged
+	default:
+		return want
+	}
+}
+
+// toGenericMap 通过 JSON 往返将任意配置转换为统一的通用结构，便于比较
+// YAML 解析得到的整数和 JSON 解析得到的浮点数经过往返后类型一致
+func toGenericMap(v interface{}) (map[string]interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	result := map[string]interface{}{}
+	if err := json.Unmarshal(data, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// sortedKeys 返回排序后的 map 键
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,239 @@
+package cdn
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// liveDomainConfig 模拟线上配置接口返回的配置
+var liveDomainConfig = map[string]interface{}{
+	"business_type": "web",
+	"sources": []map[string]interface{}{
+		{"origin_type": "domain", "origin_addr": "origin.example.com", "priority": 70, "http_port": 80, "https_port": 443},
+	},
+	"cache_rules": []map[string]interface{}{
+		{"match_type": "all", "ttl": 30, "ttl_unit": "d", "priority": 1},
+	},
+	"compress": map[string]interface{}{"status": "off"},
+}
+
+func TestGetDomainConfig(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/configuration/domains/cdn.example.com/configs") {
+			t.Errorf("请求路径错误: %s", r.URL.Path)
+		}
+		writeJSON(t, w, map[string]interface{}{"configs": liveDomainConfig})
+	})
+
+	config, err := client.GetDomainConfig("cdn.example.com")
+	if err != nil {
+		t.Fatalf("查询失败: %v", err)
+	}
+	if config.Domain != "cdn.example.com" || config.Configs["business_type"] != "web" {
+		t.Errorf("配置转换错误: %+v", config)
+	}
+	if sources, ok := config.Configs["sources"].([]interface{}); !ok || len(sources) != 1 {
+		t.Errorf("源站配置错误: %+v", config.Configs["sources"])
+	}
+}
+
+func TestParseDomainConfig(t *testing.T) {
+	config, err := ParseDomainConfig([]byte(`
+domain: cdn.example.com
+configs:
+  compress:
+    status: "on"
+    type: gzip
+`))
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if config.Domain != "cdn.example.com" || config.Configs["compress"] == nil {
+		t.Errorf("解析结果错误: %+v", config)
+	}
+
+	for _, data := range []string{"domain: [", "configs:\n  compress: {}", "domain: cdn.example.com"} {
+		if _, err := ParseDomainConfig([]byte(data)); err == nil {
+			t.Errorf("期望 %q 解析失败", data)
+		}
+	}
+}
+
+func TestDiffDomainConfig(t *testing.T) {
+	live := &DomainConfig{Domain: "cdn.example.com", Configs: liveDomainConfig}
+	desired, err := ParseDomainConfig([]byte(`
+domain: cdn.example.com
+configs:
+  business_type: download
+  sources:
+    - origin_type: domain
+      origin_addr: origin.example.com
+      priority: 70
+      http_port: 80
+      https_port: 443
+  cache_rules:
+    - match_type: all
+      ttl: 7
+      ttl_unit: d
+      priority: 1
+  referer:
+    type: black
+    value: bad.example.com
+`))
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+
+	diff, err := DiffDomainConfig(live, desired)
+	if err != nil {
+		t.Fatalf("比较失败: %v", err)
+	}
+
+	// YAML 中的整数与接口返回的数字应视为相同，sources 不应出现在差异中
+	if len(diff.Changes) != 2 {
+		t.Fatalf("期望 2 个变更，实际为 %+v", diff.Changes)
+	}
+	if diff.Changes[0].Section != "cache_rules" || diff.Changes[0].Action != ConfigActionUpdate {
+		t.Errorf("cache_rules 变更错误: %+v", diff.Changes[0])
+	}
+	if diff.Changes[1].Section != "referer" || diff.Changes[1].Action != ConfigActionAdd || diff.Changes[1].Current != nil {
+		t.Errorf("referer 变更错误: %+v", diff.Changes[1])
+	}
+	if len(diff.Ignored) != 1 || diff.Ignored[0] != "business_type" {
+		t.Errorf("期望忽略 business_type，实际为 %v", diff.Ignored)
+	}
+}
+
+func TestDiffDomainConfigPartialSections(t *testing.T) {
+	// 线上配置包含接口填充的默认值和期望配置中没有的字段
+	live := &DomainConfig{Domain: "cdn.example.com", Configs: map[string]interface{}{
+		"sources": []map[string]interface{}{
+			{"origin_type": "domain", "origin_addr": "origin.example.com", "priority": 70, "weight": 1,
+				"host_name": "origin.example.com", "obs_web_hosting_status": "off", "http_port": 80, "https_port": 443},
+		},
+		"compress": map[string]interface{}{"status": "on", "type": "gzip", "file_type": ".js,.html,.css"},
+	}}
+	desired, err := ParseDomainConfig([]byte(`
+domain: cdn.example.com
+configs:
+  sources:
+    - origin_type: domain
+      origin_addr: origin.example.com
+      priority: 70
+  compress:
+    status: "on"
+    type: gzip
+`))
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+
+	diff, err := DiffDomainConfig(live, desired)
+	if err != nil {
+		t.Fatalf("比较失败: %v", err)
+	}
+	if diff.HasChanges() {
+		t.Errorf("期望配置是线上配置的子集时不应有变更，实际为 %+v", diff.Changes)
+	}
+
+	// 数组逐个元素比较，元素数量不同或字段值不同时视为变更
+	desired.Configs["sources"] = []interface{}{
+		map[string]interface{}{"origin_addr": "origin.example.com"},
+		map[string]interface{}{"origin_addr": "backup.example.com"},
+	}
+	desired.Configs["compress"] = map[string]interface{}{"status": "off"}
+	if diff, err = DiffDomainConfig(live, desired); err != nil {
+		t.Fatalf("比较失败: %v", err)
+	}
+	if len(diff.Changes) != 2 {
+		t.Errorf("期望 2 个变更，实际为 %+v", diff.Changes)
+	}
+}
+
+func TestApplyDomainConfigOnlyChangedSections(t *testing.T) {
+	var body map[string]map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("期望 PUT 请求，实际为 %s", r.Method)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("解析请求失败: %v", err)
+		}
+		writeJSON(t, w, map[string]interface{}{})
+	})
+
+	diff := &ConfigDiff{
+		Domain: "cdn.example.com",
+		Changes: []ConfigChange{
+			{Section: "compress", Action: ConfigActionUpdate, Desired: map[string]interface{}{"status": "on", "type": "gzip"}},
+			{Section: "ip_filter", Action: ConfigActionAdd, Desired: map[string]interface{}{"type": "black", "value": "1.2.3.4"}},
+		},
+	}
+	if err := client.ApplyDomainConfig(diff); err != nil {
+		t.Fatalf("更新失败: %v", err)
+	}
+
+	configs := body["configs"]
+	if len(configs) != 2 || configs["compress"] == nil || configs["ip_filter"] == nil {
+		t.Errorf("请求应只包含变更的配置项，实际为 %v", configs)
+	}
+}
+
+func TestApplyDomainConfigKeepsLiveFields(t *testing.T) {
+	var body struct {
+		Configs map[string]interface{} `json:"configs"`
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("解析请求失败: %v", err)
+		}
+		writeJSON(t, w, map[string]interface{}{})
+	})
+
+	live := &DomainConfig{Domain: "cdn.example.com", Configs: map[string]interface{}{
+		"referer":  map[string]interface{}{"type": "white", "value": "a.example.com", "include_empty": false},
+		"compress": map[string]interface{}{"status": "on", "type": "gzip", "file_type": ".js,.css"},
+		"sources": []map[string]interface{}{
+			{"origin_type": "domain", "origin_addr": "origin.example.com", "priority": 70, "http_port": 8080, "https_port": 8443},
+		},
+	}}
+	desired := &DomainConfig{Domain: "cdn.example.com", Configs: map[string]interface{}{
+		"referer":  map[string]interface{}{"value": "a.example.com,b.example.com"},
+		"compress": map[string]interface{}{"type": "br"},
+		"sources":  []map[string]interface{}{{"origin_addr": "new-origin.example.com"}},
+	}}
+	diff, err := DiffDomainConfig(live, desired)
+	if err != nil {
+		t.Fatalf("比较失败: %v", err)
+	}
+	if err := client.ApplyDomainConfig(diff); err != nil {
+		t.Fatalf("更新失败: %v", err)
+	}
+
+	// 期望配置未指定的线上字段应保留在请求中
+	configs, _ := toGenericMap(body.Configs)
+	expected := map[string]interface{}{
+		"referer":  map[string]interface{}{"type": "white", "value": "a.example.com,b.example.com", "include_empty": false},
+		"compress": map[string]interface{}{"status": "on", "type": "br", "file_type": ".js,.css"},
+		"sources": []interface{}{map[string]interface{}{
+			"origin_type": "domain", "origin_addr": "new-origin.example.com", "priority": float64(70),
+			"http_port": float64(8080), "https_port": float64(8443),
+		}},
+	}
+	if !reflect.DeepEqual(configs, expected) {
+		t.Errorf("请求应合并线上配置\n期望: %v\n实际: %v", expected, configs)
+	}
+}
+
+func TestApplyDomainConfigNoChanges(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("没有变更时不应发送请求: %s %s", r.Method, r.URL.Path)
+	})
+
+	if err := client.ApplyDomainConfig(&ConfigDiff{Domain: "cdn.example.com"}); err != nil {
+		t.Fatalf("期望成功，实际为 %v", err)
+	}
+}
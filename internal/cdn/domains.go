@@ -0,0 +1,453 @@
+package cdn
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2/model"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+)
+
+// 加速域名分页参数
+const (
+	// DefaultDomainListPageSize 查询加速域名时的默认每页数量
+	DefaultDomainListPageSize = 30
+	// MaxDomainListPageSize 加速域名接口允许的最大每页数量
+	MaxDomainListPageSize = 10000
+	// domainListPageSize 自动翻页获取全部域名时的每页数量
+	domainListPageSize = 1000
+)
+
+// AllEnterpriseProjects 查询所有企业项目下的资源时使用的企业项目ID
+const AllEnterpriseProjects = "ALL"
+
+// BusinessTypes 支持的业务类型
+var BusinessTypes = []string{"web", "download", "video", "wholeSite"}
+
+// ServiceAreas 支持的服务范围
+var ServiceAreas = []string{"mainland_china", "outside_mainland_china", "global"}
+
+// OriginTypes 支持的源站类型
+var OriginTypes = []string{"ipaddr", "domain", "obs_bucket"}
+
+// DomainStatuses 支持的域名状态过滤值
+var DomainStatuses = []string{"online", "offline", "configuring", "configure_failed", "checking", "check_failed", "deleting"}
+
+// Domain 加速域名信息
+type Domain struct {
+	ID           string `json:"id" table:"域名ID"`
+	Name         string `json:"domain_name" table:"域名"`
+	BusinessType string `json:"business_type" table:"业务类型"`
+	ServiceArea  string `json:"service_area" table:"服务范围"`
+	Status       string `json:"status" table:"状态"`
+	CNAME        string `json:"cname" table:"CNAME"`
+	HTTPS        bool   `json:"https" table:"HTTPS"`
+	Disabled     bool   `json:"disabled" table:"已封禁"`
+	CreatedAt    string `json:"created_at,omitempty" table:"创建时间"`
+}
+
+// DomainOrigin 加速域名的源站信息
+type DomainOrigin struct {
+	Type      string `json:"type" yaml:"type" table:"源站类型"`
+	Address   string `json:"address" yaml:"address" table:"源站地址"`
+	Primary   bool   `json:"primary" yaml:"primary" table:"主源站"`
+	HTTPPort  int    `json:"http_port,omitempty" yaml:"http_port,omitempty" table:"HTTP端口"`
+	HTTPSPort int    `json:"https_port,omitempty" yaml:"https_port,omitempty" table:"HTTPS端口"`
+}
+
+// DomainDetail 加速域名详情
+type DomainDetail struct {
+	Domain
+	Origins []DomainOrigin `json:"origins"`
+}
+
+// DomainList 加速域名查询结果
+type DomainList struct {
+	Total   int      `json:"total"`
+	Domains []Domain `json:"domains"`
+}
+
+// DomainListOptions 加速域名查询条件
+type DomainListOptions struct {
+	Name         string // 域名，模糊匹配
+	BusinessType string
+	ServiceArea  string
+	Status       string
+	PageSize     int
+	PageNumber   int
+	All          bool // 为 true 时从 PageNumber 开始自动翻页获取全部域名
+	// EnterpriseProjectID 为空时使用凭证中配置的企业项目，AllEnterpriseProjects 查询所有企业项目
+	EnterpriseProjectID string
+}
+
+// DomainCreateOptions 创建加速域名的参数
+type DomainCreateOptions struct {
+	Name         string
+	BusinessType string
+	ServiceArea  string
+	Origins      []DomainOrigin
+}
+
+// ListDomains 查询加速域名
+func (c *Client) ListDomains(opts DomainListOptions) (*DomainList, error) {
+	logx.Debugf("查询加速域名，条件: %+v", opts)
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultDomainListPageSize
+	}
+	if pageSize > MaxDomainListPageSize {
+		pageSize = MaxDomainListPageSize
+	}
+	startPage := opts.PageNumber
+	if startPage <= 0 {
+		startPage = 1
+	}
+
+	enterpriseProjectID := opts.EnterpriseProjectID
+	if enterpriseProjectID == "" {
+		enterpriseProjectID = c.enterpriseProjectID()
+	}
+	size := int32(pageSize)
+	request := &model.ListDomainsRequest{
+		EnterpriseProjectId: &enterpriseProjectID,
+		PageSize:            &size,
+	}
+	if opts.Name != "" {
+		request.DomainName = &opts.Name
+	}
+	if opts.BusinessType != "" {
+		request.BusinessType = &opts.BusinessType
+	}
+	if opts.ServiceArea != "" {
+		request.ServiceArea = &opts.ServiceArea
+	}
+	if opts.Status != "" {
+		request.DomainStatus = &opts.Status
+	}
+
+	result := &DomainList{Domains: []Domain{}}
+	for page := int32(startPage); page <= maxTaskDetailsPageNumber; page++ {
+		pageNumber := page
+		request.PageNumber = &pageNumber
+
+		response, err := c.cdnClient.ListDomains(request)
+		if err != nil {
+			logx.Debugf("查询加速域名失败: %v", err)
+			return nil, hwErrors.FromSDKError(err)
+		}
+
+		result.Total = getIntValue(response.Total)
+		domains := []model.Domains{}
+		if response.Domains != nil {
+			domains = *response.Domains
+		}
+		for i := range domains {
+			result.Domains = append(result.Domains, convertToDomain(&domains[i]))
+		}
+
+		logx.Debugf("加速域名第 %d 页返回 %d 个，共 %d 个", page, len(domains), result.Total)
+		if !opts.All || len(domains) < pageSize || len(result.Domains) >= result.Total {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// ListDomainNames 查询当前账号下所有企业项目中加速域名的名称，自动翻页
+func (c *Client) ListDomainNames() ([]string, error) {
+	list, err := c.ListDomains(DomainListOptions{
+		PageSize:            domainListPageSize,
+		All:                 true,
+		EnterpriseProjectID: AllEnterpriseProjects,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(list.Domains))
+	for _, d := range list.Domains {
+		if d.Name != "" {
+			names = append(names, d.Name)
+		}
+	}
+	return names, nil
+}
+
+// GetDomain 按域名查询加速域名详情
+func (c *Client) GetDomain(name string) (*DomainDetail, error) {
+	logx.Debugf("查询加速域名详情: %s", name)
+
+	enterpriseProjectID := c.enterpriseProjectID()
+	response, err := c.cdnClient.ShowDomainDetailByName(&model.ShowDomainDetailByNameRequest{
+		DomainName:          name,
+		EnterpriseProjectId: &enterpriseProjectID,
+	})
+	if err != nil {
+		logx.Debugf("查询加速域名详情失败: %v", err)
+		return nil, hwErrors.FromSDKError(err)
+	}
+	if response.Domain == nil || getStringValue(response.Domain.Id) == "" {
+		return nil, hwErrors.NewNotFoundError(fmt.Sprintf("加速域名 %s", name))
+	}
+
+	return convertToDomainDetail(response.Domain), nil
+}
+
+// CreateDomain 创建加速域名
+func (c *Client) CreateDomain(opts DomainCreateOptions) (*DomainDetail, error) {
+	logx.Debugf("创建加速域名: %+v", opts)
+
+	body, err := buildDomainBody(opts)
+	if err != nil {
+		return nil, err
+	}
+	enterpriseProjectID := c.enterpriseProjectID()
+	body.EnterpriseProjectId = &enterpriseProjectID
+
+	response, err := c.cdnClient.CreateDomain(&model.CreateDomainRequest{
+		Body: &model.CreateDomainRequestBody{Domain: body},
+	})
+	if err != nil {
+		logx.Debugf("创建加速域名失败: %v", err)
+		return nil, hwErrors.FromSDKError(err)
+	}
+	if response.Domain == nil {
+		return nil, hwErrors.NewServerError("创建加速域名响应为空")
+	}
+
+	d := response.Domain
+	detail := &DomainDetail{
+		Domain: Domain{
+			ID:           getStringValue(d.Id),
+			Name:         getStringValue(d.DomainName),
+			BusinessType: getStringValue(d.BusinessType),
+			ServiceArea:  getStringValue(d.ServiceArea),
+			Status:       getStringValue(d.DomainStatus),
+			CNAME:        getStringValue(d.Cname),
+			HTTPS:        getIntValue(d.HttpsStatus) != 0,
+			Disabled:     getIntValue(d.Disabled) != 0,
+		},
+		Origins: []DomainOrigin{},
+	}
+	if d.CreateTime != nil {
+		detail.CreatedAt = formatMillis(*d.CreateTime)
+	}
+	if d.Sources != nil {
+		for _, s := range *d.Sources {
+			detail.Origins = append(detail.Origins, DomainOrigin{
+				Type:    s.OriginType.Value(),
+				Address: s.IpOrDomain,
+				Primary: s.ActiveStandby == 1,
+			})
+		}
+	}
+
+	logx.Infof("加速域名创建成功: %s，CNAME: %s", detail.Name, detail.CNAME)
+	return detail, nil
+}
+
+// DeleteDomain 删除加速域名，域名需先停用
+func (c *Client) DeleteDomain(name string) (*Domain, error) {
+	return c.changeDomain(name, "删除", func(domainID string, enterpriseProjectID *string) (*model.DomainsWithPort, error) {
+		response, err := c.cdnClient.DeleteDomain(&model.DeleteDomainRequest{
+			DomainId:            domainID,
+			EnterpriseProjectId: enterpriseProjectID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return response.Domain, nil
+	})
+}
+
+// EnableDomain 启用加速域名
+func (c *Client) EnableDomain(name string) (*Domain, error) {
+	return c.changeDomain(name, "启用", func(domainID string, enterpriseProjectID *string) (*model.DomainsWithPort, error) {
+		response, err := c.cdnClient.EnableDomain(&model.EnableDomainRequest{
+			DomainId:            domainID,
+			EnterpriseProjectId: enterpriseProjectID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return response.Domain, nil
+	})
+}
+
+// DisableDomain 停用加速域名
+func (c *Client) DisableDomain(name string) (*Domain, error) {
+	return c.changeDomain(name, "停用", func(domainID string, enterpriseProjectID *string) (*model.DomainsWithPort, error) {
+		response, err := c.cdnClient.DisableDomain(&model.DisableDomainRequest{
+			DomainId:            domainID,
+			EnterpriseProjectId: enterpriseProjectID,
+		})
+		if err != nil {
+			return nil, err
+		}
+		return response.Domain, nil
+	})
+}
+
+// changeDomain 按域名查询域名ID后执行删除/启用/停用操作
+func (c *Client) changeDomain(name, action string,
+	call func(domainID string, enterpriseProjectID *string) (*model.DomainsWithPort, error)) (*Domain, error) {
+	detail, err := c.GetDomain(name)
+	if err != nil {
+		return nil, err
+	}
+
+	logx.Debugf("%s加速域名: %s (ID: %s)", action, name, detail.ID)
+	enterpriseProjectID := c.enterpriseProjectID()
+	d, err := call(detail.ID, &enterpriseProjectID)
+	if err != nil {
+		logx.Debugf("%s加速域名失败: %v", action, err)
+		return nil, hwErrors.FromSDKError(err)
+	}
+
+	domain := detail.Domain
+	if d != nil {
+		domain.Status = getStringValue(d.DomainStatus)
+		domain.Disabled = getIntValue(d.Disabled) != 0
+	}
+	logx.Infof("加速域名%s成功: %s", action, name)
+	return &domain, nil
+}
+
+// buildDomainBody 校验创建参数并构建请求体
+func buildDomainBody(opts DomainCreateOptions) (*model.DomainBody, error) {
+	if opts.Name == "" {
+		return nil, hwErrors.NewValidationError("域名不能为空")
+	}
+	if err := validateHostname(strings.TrimPrefix(opts.Name, "*.")); err != nil {
+		return nil, hwErrors.NewValidationError(err.Error())
+	}
+	if len(opts.Origins) == 0 {
+		return nil, hwErrors.NewValidationError("至少需要指定一个源站")
+	}
+
+	body := &model.DomainBody{DomainName: opts.Name}
+
+	businessTypes := model.GetDomainBodyBusinessTypeEnum()
+	switch opts.BusinessType {
+	case "web":
+		body.BusinessType = businessTypes.WEB
+	case "download":
+		body.BusinessType = businessTypes.DOWNLOAD
+	case "video":
+		body.BusinessType = businessTypes.VIDEO
+	case "wholeSite":
+		body.BusinessType = businessTypes.WHOLE_SITE
+	default:
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("不支持的业务类型: %s，支持的类型: %s",
+			opts.BusinessType, strings.Join(BusinessTypes, ", ")))
+	}
+
+	serviceAreas := model.GetDomainBodyServiceAreaEnum()
+	switch opts.ServiceArea {
+	case "mainland_china":
+		body.ServiceArea = serviceAreas.MAINLAND_CHINA
+	case "outside_mainland_china":
+		body.ServiceArea = serviceAreas.OUTSIDE_MAINLAND_CHINA
+	case "global":
+		body.ServiceArea = serviceAreas.GLOBAL
+	default:
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("不支持的服务范围: %s，支持的范围: %s",
+			opts.ServiceArea, strings.Join(ServiceAreas, ", ")))
+	}
+
+	originTypes := model.GetSourcesRequestBodyOriginTypeEnum()
+	primaries := 0
+	for _, origin := range opts.Origins {
+		source := model.SourcesRequestBody{IpOrDomain: origin.Address}
+		switch origin.Type {
+		case "ipaddr":
+			source.OriginType = originTypes.IPADDR
+		case "domain":
+			source.OriginType = originTypes.DOMAIN
+		case "obs_bucket":
+			source.OriginType = originTypes.OBS_BUCKET
+		default:
+			return nil, hwErrors.NewValidationError(fmt.Sprintf("不支持的源站类型: %s，支持的类型: %s",
+				origin.Type, strings.Join(OriginTypes, ", ")))
+		}
+		if origin.Address == "" {
+			return nil, hwErrors.NewValidationError("源站地址不能为空")
+		}
+		if origin.Primary {
+			source.ActiveStandby = 1
+			primaries++
+		}
+		if origin.HTTPPort > 0 {
+			port := int32(origin.HTTPPort)
+			source.HttpPort = &port
+		}
+		if origin.HTTPSPort > 0 {
+			port := int32(origin.HTTPSPort)
+			source.HttpsPort = &port
+		}
+		body.Sources = append(body.Sources, source)
+	}
+	if primaries != 1 {
+		return nil, hwErrors.NewValidationError("必须且只能指定一个主源站")
+	}
+
+	return body, nil
+}
+
+// convertToDomain 转换域名列表中的域名对象
+func convertToDomain(d *model.Domains) Domain {
+	domain := Domain{
+		ID:           getStringValue(d.Id),
+		Name:         getStringValue(d.DomainName),
+		BusinessType: getStringValue(d.BusinessType),
+		Status:       getStringValue(d.DomainStatus),
+		CNAME:        getStringValue(d.Cname),
+		HTTPS:        getIntValue(d.HttpsStatus) != 0,
+		Disabled:     getIntValue(d.Disabled) != 0,
+	}
+	if d.ServiceArea != nil {
+		domain.ServiceArea = d.ServiceArea.Value()
+	}
+	if d.CreateTime != nil {
+		domain.CreatedAt = formatMillis(*d.CreateTime)
+	}
+	return domain
+}
+
+// convertToDomainDetail 转换域名详情对象
+func convertToDomainDetail(d *model.DomainsDetail) *DomainDetail {
+	detail := &DomainDetail{
+		Domain: Domain{
+			ID:           getStringValue(d.Id),
+			Name:         getStringValue(d.DomainName),
+			BusinessType: getStringValue(d.BusinessType),
+			Status:       getStringValue(d.DomainStatus),
+			CNAME:        getStringValue(d.Cname),
+			HTTPS:        getIntValue(d.HttpsStatus) != 0,
+			Disabled:     getIntValue(d.Disabled) != 0,
+		},
+		Origins: []DomainOrigin{},
+	}
+	if d.ServiceArea != nil {
+		detail.ServiceArea = d.ServiceArea.Value()
+	}
+	if d.CreateTime != nil {
+		detail.CreatedAt = formatMillis(*d.CreateTime)
+	}
+	// 域名详情中源站优先级 70 为主源站，30 为备源站
+	if d.Sources != nil {
+		for _, s := range *d.Sources {
+			detail.Origins = append(detail.Origins, DomainOrigin{
+				Type:      s.OriginType,
+				Address:   s.OriginAddr,
+				Primary:   s.Priority == 70,
+				HTTPPort:  getIntValue(s.HttpPort),
+				HTTPSPort: getIntValue(s.HttpsPort),
+			})
+		}
+	}
+	return detail
+}
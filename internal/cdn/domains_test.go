@@ -0,0 +1,179 @@
+package cdn
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestListDomainsAllPages(t *testing.T) {
+	var pages []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		pages = append(pages, query.Get("page_number"))
+		if query.Get("domain_status") != "online" {
+			t.Errorf("期望按状态过滤，实际为 %s", query.Get("domain_status"))
+		}
+
+		name := "a.example.com"
+		if query.Get("page_number") == "2" {
+			name = "b.example.com"
+		}
+		writeJSON(t, w, map[string]interface{}{
+			"total": 2,
+			"domains": []map[string]interface{}{
+				{"id": "id-" + name, "domain_name": name, "business_type": "web", "domain_status": "online",
+					"service_area": "mainland_china", "https_status": 2, "disabled": 0, "create_time": 1700000000000},
+			},
+		})
+	})
+
+	list, err := client.ListDomains(DomainListOptions{Status: "online", PageSize: 1, All: true})
+	if err != nil {
+		t.Fatalf("查询失败: %v", err)
+	}
+	if len(list.Domains) != 2 || list.Total != 2 || len(pages) != 2 {
+		t.Fatalf("期望翻页获取 2 个域名，实际为 %+v，请求页码 %v", list, pages)
+	}
+	d := list.Domains[0]
+	if d.Name != "a.example.com" || d.ServiceArea != "mainland_china" || !d.HTTPS || d.Disabled {
+		t.Errorf("域名转换错误: %+v", d)
+	}
+}
+
+func TestGetDomain(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/configuration/domains/cdn.example.com") {
+			t.Errorf("请求路径错误: %s", r.URL.Path)
+		}
+		writeJSON(t, w, map[string]interface{}{
+			"domain": map[string]interface{}{
+				"id": "domain-1", "domain_name": "cdn.example.com", "domain_status": "online",
+				"sources": []map[string]interface{}{
+					{"origin_type": "domain", "origin_addr": "origin.example.com", "priority": 70, "http_port": 80},
+					{"origin_type": "ipaddr", "origin_addr": "1.2.3.4", "priority": 30},
+				},
+			},
+		})
+	})
+
+	detail, err := client.GetDomain("cdn.example.com")
+	if err != nil {
+		t.Fatalf("查询失败: %v", err)
+	}
+	if detail.ID != "domain-1" || len(detail.Origins) != 2 {
+		t.Fatalf("域名详情错误: %+v", detail)
+	}
+	if !detail.Origins[0].Primary || detail.Origins[1].Primary || detail.Origins[0].HTTPPort != 80 {
+		t.Errorf("源站转换错误: %+v", detail.Origins)
+	}
+}
+
+func TestDisableDomainResolvesID(t *testing.T) {
+	var paths []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Method+" "+r.URL.Path)
+		if strings.Contains(r.URL.Path, "/configuration/domains/") {
+			writeJSON(t, w, map[string]interface{}{
+				"domain": map[string]interface{}{"id": "domain-1", "domain_name": "cdn.example.com", "domain_status": "online"},
+			})
+			return
+		}
+		writeJSON(t, w, map[string]interface{}{
+			"domain": map[string]interface{}{"id": "domain-1", "domain_status": "offline"},
+		})
+	})
+
+	domain, err := client.DisableDomain("cdn.example.com")
+	if err != nil {
+		t.Fatalf("停用失败: %v", err)
+	}
+	if domain.Status != "offline" || domain.Name != "cdn.example.com" {
+		t.Errorf("停用结果错误: %+v", domain)
+	}
+	if len(paths) != 2 || !strings.HasSuffix(paths[1], "/domains/domain-1/disable") {
+		t.Errorf("期望先查询域名ID再停用，实际请求为 %v", paths)
+	}
+}
+
+func TestCreateDomain(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Domain struct {
+				DomainName   string `json:"domain_name"`
+				BusinessType string `json:"business_type"`
+				Sources      []struct {
+					IpOrDomain    string `json:"ip_or_domain"`
+					OriginType    string `json:"origin_type"`
+					ActiveStandby int    `json:"active_standby"`
+				} `json:"sources"`
+			} `json:"domain"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("解析请求体失败: %v", err)
+		}
+		if body.Domain.DomainName != "cdn.example.com" || body.Domain.BusinessType != "download" {
+			t.Errorf("请求体错误: %+v", body.Domain)
+		}
+		if len(body.Domain.Sources) != 2 || body.Domain.Sources[0].ActiveStandby != 1 || body.Domain.Sources[1].ActiveStandby != 0 {
+			t.Errorf("源站主备设置错误: %+v", body.Domain.Sources)
+		}
+		writeJSON(t, w, map[string]interface{}{
+			"domain": map[string]interface{}{
+				"id": "domain-1", "domain_name": "cdn.example.com", "cname": "cdn.example.com.c.cdnhwc1.com",
+				"domain_status": "configuring",
+			},
+		})
+	})
+
+	detail, err := client.CreateDomain(DomainCreateOptions{
+		Name:         "cdn.example.com",
+		BusinessType: "download",
+		ServiceArea:  "global",
+		Origins: []DomainOrigin{
+			{Type: "domain", Address: "origin.example.com", Primary: true},
+			{Type: "ipaddr", Address: "1.2.3.4"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("创建失败: %v", err)
+	}
+	if detail.CNAME == "" || detail.Status != "configuring" {
+		t.Errorf("创建结果错误: %+v", detail)
+	}
+}
+
+func TestBuildDomainBodyValidation(t *testing.T) {
+	valid := DomainCreateOptions{
+		Name:         "cdn.example.com",
+		BusinessType: "web",
+		ServiceArea:  "mainland_china",
+		Origins:      []DomainOrigin{{Type: "ipaddr", Address: "1.2.3.4", Primary: true}},
+	}
+	if _, err := buildDomainBody(valid); err != nil {
+		t.Fatalf("期望参数有效，实际为 %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		mutate func(o *DomainCreateOptions)
+	}{
+		{"域名为空", func(o *DomainCreateOptions) { o.Name = "" }},
+		{"域名无效", func(o *DomainCreateOptions) { o.Name = "bad_domain.com" }},
+		{"业务类型无效", func(o *DomainCreateOptions) { o.BusinessType = "game" }},
+		{"服务范围无效", func(o *DomainCreateOptions) { o.ServiceArea = "mars" }},
+		{"没有源站", func(o *DomainCreateOptions) { o.Origins = nil }},
+		{"源站类型无效", func(o *DomainCreateOptions) { o.Origins = []DomainOrigin{{Type: "ftp", Address: "a", Primary: true}} }},
+		{"没有主源站", func(o *DomainCreateOptions) { o.Origins = []DomainOrigin{{Type: "ipaddr", Address: "1.2.3.4"}} }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := valid
+			tt.mutate(&opts)
+			if _, err := buildDomainBody(opts); err == nil {
+				t.Error("期望返回错误")
+			}
+		})
+	}
+}
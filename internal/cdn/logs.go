@@ -0,0 +1,405 @@
+package cdn
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2/model"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/retry"
+	"github.com/ygqygq2/hwcctl/internal/utils"
+)
+
+// logListPageSize 查询日志列表时的每页数量
+const logListPageSize = 1000
+
+// maxLogQueryRange 单次查询日志的最大时间跨度
+const maxLogQueryRange = 30 * 24 * time.Hour
+
+// logDownloadTimeout 单次下载请求的超时时间，超时后由重试从已下载的位置继续
+const logDownloadTimeout = 10 * time.Minute
+
+// partialFileSuffix 未下载完成的日志文件后缀，再次下载时从该文件断点续传
+const partialFileSuffix = ".part"
+
+// 日志下载状态
+const (
+	LogStatusDownloaded = "downloaded"
+	LogStatusResumed    = "resumed"
+	LogStatusSkipped    = "skipped"
+	LogStatusFailed     = "failed"
+)
+
+// LogFile CDN 访问日志文件，每个文件包含一个小时的日志
+type LogFile struct {
+	Domain    string `json:"domain_name" table:"域名"`
+	Name      string `json:"name" table:"文件名"`
+	StartTime string `json:"start_time" table:"开始时间"`
+	EndTime   string `json:"end_time" table:"结束时间"`
+	Size      int64  `json:"size" table:"大小"`
+	Link      string `json:"link" table:"下载链接"`
+}
+
+// LogDownloadOptions 下载日志的参数
+type LogDownloadOptions struct {
+	// Dir 日志保存目录，不存在时自动创建
+	Dir string
+	// Concurrency 同时下载的文件数量，小于等于 0 时使用默认值
+	Concurrency int
+	// Retryer 单个文件下载失败时的重试器，重试时从已下载的位置继续
+	Retryer *retry.Retryer
+	// HTTPClient 下载使用的 HTTP 客户端，为 nil 时使用带超时的默认客户端
+	HTTPClient *http.Client
+	// Progress 不为 nil 时向其输出整体下载进度
+	Progress io.Writer
+}
+
+// LogDownloadResult 单个日志文件的下载结果
+type LogDownloadResult struct {
+	Name   string `json:"name" table:"文件名"`
+	Path   string `json:"path" table:"保存路径"`
+	Size   int64  `json:"size" table:"大小"`
+	Status string `json:"status" table:"状态"`
+	Error  string `json:"error,omitempty" table:"错误"`
+}
+
+// ListLogs 查询加速域名在 [start, end) 内的访问日志文件
+// 开始时间向前、结束时间向后对齐到整点，时间跨度不能超过 30 天，结果按时间排序
+func (c *Client) ListLogs(domain string, start, end time.Time) ([]LogFile, error) {
+	if domain == "" {
+		return nil, hwErrors.NewValidationError("域名不能为空")
+	}
+	start = start.Truncate(time.Hour)
+	if aligned := end.Truncate(time.Hour); !aligned.Equal(end) {
+		end = aligned.Add(time.Hour)
+	}
+	if !start.Before(end) {
+		return nil, hwErrors.NewValidationError("开始时间必须早于结束时间")
+	}
+	if end.Sub(start) > maxLogQueryRange {
+		return nil, hwErrors.NewValidationError("日志查询的时间跨度不能超过 30 天")
+	}
+
+	enterpriseProjectID := c.enterpriseProjectID()
+	startTime, endTime := start.UnixMilli(), end.UnixMilli()
+	size := int32(logListPageSize)
+	request := &model.ShowLogsRequest{
+		DomainName:          domain,
+		StartTime:           &startTime,
+		EndTime:             &endTime,
+		PageSize:            &size,
+		EnterpriseProjectId: &enterpriseProjectID,
+	}
+
+	logs := []LogFile{}
+	for page := int32(1); page <= maxTaskDetailsPageNumber; page++ {
+		pageNumber := page
+		request.PageNumber = &pageNumber
+
+		response, err := c.cdnClient.ShowLogs(request)
+		if err != nil {
+			logx.Debugf("查询域名 %s 的日志失败: %v", domain, err)
+			return nil, hwErrors.FromSDKError(err)
+		}
+
+		objects := []model.LogObject{}
+		if response.Logs != nil {
+			objects = *response.Logs
+		}
+		for i := range objects {
+			logs = append(logs, convertToLogFile(&objects[i]))
+		}
+
+		logx.Debugf("日志第 %d 页返回 %d 个，共 %d 个", page, len(objects), getIntValue(response.Total))
+		if len(objects) < logListPageSize || len(logs) >= getIntValue(response.Total) {
+			break
+		}
+	}
+
+	sort.SliceStable(logs, func(i, j int) bool {
+		if logs[i].StartTime != logs[j].StartTime {
+			return logs[i].StartTime < logs[j].StartTime
+		}
+		return logs[i].Name < logs[j].Name
+	})
+	return logs, nil
+}
+
+// convertToLogFile 将 SDK 的日志对象转换为 LogFile
+func convertToLogFile(obj *model.LogObject) LogFile {
+	file := LogFile{
+		Domain: getStringValue(obj.DomainName),
+		Name:   getStringValue(obj.Name),
+		Link:   getStringValue(obj.Link),
+		Size:   getInt64Value(obj.Size),
+	}
+	if obj.StartTime != nil {
+		file.StartTime = formatMillis(*obj.StartTime)
+	}
+	if obj.EndTime != nil {
+		file.EndTime = formatMillis(*obj.EndTime)
+	}
+	return file
+}
+
+// DownloadLogs 并发下载日志文件到 opts.Dir
+// 已完整存在的文件会被跳过，未完成的文件（.part）从已下载的位置继续，下载完成后校验文件大小。
+// 单个文件失败不会中断其他文件，所有文件处理完后返回汇总错误。
+func DownloadLogs(ctx context.Context, files []LogFile, opts LogDownloadOptions) ([]LogDownloadResult, error) {
+	if opts.Dir == "" {
+		opts.Dir = "."
+	}
+	if err := os.MkdirAll(opts.Dir, 0o755); err != nil {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("创建目录 %s 失败: %v", opts.Dir, err))
+	}
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = newLogHTTPClient()
+	}
+
+	progress := newDownloadProgress(opts.Progress, files)
+	results := make([]LogDownloadResult, len(files))
+	errs := forEachConcurrently(len(files), opts.Concurrency, func(i int) error {
+		file := files[i]
+		path := filepath.Join(opts.Dir, filepath.Base(file.Name))
+		results[i] = LogDownloadResult{Name: file.Name, Path: path, Size: file.Size}
+
+		var status string
+		err := withRetry(ctx, opts.Retryer, func() error {
+			var err error
+			status, err = downloadLogFile(ctx, httpClient, file, path, func(n int64) {
+				progress.update(i, n)
+			})
+			return err
+		})
+		if err != nil {
+			logx.Debugf("下载日志 %s 失败: %v", file.Name, err)
+			results[i].Status = LogStatusFailed
+			results[i].Error = err.Error()
+			return err
+		}
+		progress.update(i, file.Size)
+		results[i].Status = status
+		return nil
+	})
+	progress.finish()
+
+	failed := 0
+	var firstErr error
+	for _, err := range errs {
+		if err != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("%d/%d 个日志文件下载失败，%w", failed, len(files), firstErr)
+	}
+	return results, nil
+}
+
+// downloadLogFile 下载单个日志文件到 path，返回下载状态
+// 先写入 path.part，大小校验通过后再重命名为 path；中断时保留 .part 文件以便续传。
+func downloadLogFile(ctx context.Context, httpClient *http.Client, file LogFile, path string, progress func(n int64)) (string, error) {
+	if info, err := os.Stat(path); err == nil && (file.Size == 0 || info.Size() == file.Size) {
+		logx.Debugf("日志 %s 已存在，跳过下载", path)
+		return LogStatusSkipped, nil
+	}
+
+	partPath := path + partialFileSuffix
+	var offset int64
+	if info, err := os.Stat(partPath); err == nil {
+		offset = info.Size()
+		if file.Size > 0 && offset > file.Size {
+			// 未完成文件比远端文件还大，无法续传，重新下载
+			offset = 0
+		}
+	}
+
+	status := LogStatusDownloaded
+	if offset > 0 {
+		status = LogStatusResumed
+	}
+
+	if file.Size == 0 || offset < file.Size {
+		resp, err := openLogLink(ctx, httpClient, file, offset)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+
+		flags := os.O_CREATE | os.O_WRONLY | os.O_APPEND
+		if resp.StatusCode != http.StatusPartialContent {
+			// 服务端不支持 Range 时返回完整文件，从头写入
+			flags = os.O_CREATE | os.O_WRONLY | os.O_TRUNC
+			offset = 0
+			status = LogStatusDownloaded
+		}
+		logx.Debugf("下载日志 %s，起始位置 %d 字节", file.Name, offset)
+
+		out, err := os.OpenFile(partPath, flags, 0o644)
+		if err != nil {
+			return "", hwErrors.NewValidationError(fmt.Sprintf("创建文件 %s 失败: %v", partPath, err))
+		}
+		written, err := utils.CopyWithProgress(out, resp.Body, func(written int64) {
+			progress(offset + written)
+		})
+		closeErr := out.Close()
+		if err != nil {
+			return "", hwErrors.NewNetworkError(fmt.Sprintf("下载 %s 中断: %v", file.Name, err))
+		}
+		if closeErr != nil {
+			return "", hwErrors.NewValidationError(fmt.Sprintf("写入文件 %s 失败: %v", partPath, closeErr))
+		}
+		offset += written
+	}
+
+	if file.Size > 0 && offset != file.Size {
+		if offset > file.Size {
+			os.Remove(partPath)
+			return "", hwErrors.NewServerError(fmt.Sprintf("日志 %s 大小不一致: 期望 %d 字节，实际 %d 字节",
+				file.Name, file.Size, offset))
+		}
+		return "", hwErrors.NewNetworkError(fmt.Sprintf("日志 %s 下载不完整: 期望 %d 字节，实际 %d 字节",
+			file.Name, file.Size, offset))
+	}
+
+	if err := os.Rename(partPath, path); err != nil {
+		return "", hwErrors.NewValidationError(fmt.Sprintf("保存文件 %s 失败: %v", path, err))
+	}
+	return status, nil
+}
+
+// openLogLink 请求日志下载链接，offset 大于 0 时请求从该位置开始的内容
+func openLogLink(ctx context.Context, httpClient *http.Client, file LogFile, offset int64) (*http.Response, error) {
+	if file.Link == "" {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("日志 %s 没有下载链接", file.Name))
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, file.Link, nil)
+	if err != nil {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("无效的下载链接: %v", err))
+	}
+	if offset > 0 {
+		req.Header.Set("Range", fmt.Sprintf("bytes=%d-", offset))
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, hwErrors.NewNetworkError(fmt.Sprintf("下载 %s 失败: %v", file.Name, err))
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusPartialContent {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		resp.Body.Close()
+		return nil, hwErrors.NewHTTPError(resp.StatusCode, string(body))
+	}
+	return resp, nil
+}
+
+// newLogHTTPClient 创建下载日志使用的 HTTP 客户端，开启 --debug-http 时记录下载请求
+func newLogHTTPClient() *http.Client {
+	return &http.Client{Timeout: logDownloadTimeout, Transport: logx.HTTPTransport(nil)}
+}
+
+// StreamLogs 按顺序下载日志文件并将解压后的内容写入 w，不保存到本地
+// gzip 格式的文件自动解压，其他文件原样输出
+func StreamLogs(ctx context.Context, files []LogFile, w io.Writer, opts LogDownloadOptions) error {
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = newLogHTTPClient()
+	}
+
+	for _, file := range files {
+		var resp *http.Response
+		err := withRetry(ctx, opts.Retryer, func() error {
+			var err error
+			resp, err = openLogLink(ctx, httpClient, file, 0)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		err = copyLogContent(w, resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return hwErrors.NewNetworkError(fmt.Sprintf("读取日志 %s 失败: %v", file.Name, err))
+		}
+	}
+	return nil
+}
+
+// copyLogContent 将日志内容写入 w，以 gzip 魔数开头的内容先解压
+func copyLogContent(w io.Writer, r io.Reader) error {
+	br := bufio.NewReader(r)
+	magic, err := br.Peek(2)
+	if err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		gz, err := gzip.NewReader(br)
+		if err != nil {
+			return err
+		}
+		defer gz.Close()
+		_, err = io.Copy(w, gz)
+		return err
+	}
+	_, err = io.Copy(w, br)
+	return err
+}
+
+// downloadProgress 汇总多个文件的下载进度
+type downloadProgress struct {
+	mu         sync.Mutex
+	w          io.Writer
+	sizes      []int64
+	written    []int64
+	totalBytes int64
+	doneBytes  int64
+}
+
+// newDownloadProgress 创建下载进度，w 为 nil 时不输出
+func newDownloadProgress(w io.Writer, files []LogFile) *downloadProgress {
+	p := &downloadProgress{w: w, sizes: make([]int64, len(files)), written: make([]int64, len(files))}
+	for i, file := range files {
+		p.sizes[i] = file.Size
+		p.totalBytes += file.Size
+	}
+	return p
+}
+
+// update 记录第 i 个文件已下载的字节数
+func (p *downloadProgress) update(i int, n int64) {
+	if p.w == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.doneBytes += n - p.written[i]
+	p.written[i] = n
+	doneFiles := 0
+	for j, written := range p.written {
+		if p.sizes[j] > 0 && written >= p.sizes[j] {
+			doneFiles++
+		}
+	}
+	fmt.Fprintf(p.w, "\r下载进度: %d/%d 个文件 (%s/%s)", doneFiles, len(p.sizes),
+		utils.FormatBytes(float64(p.doneBytes)), utils.FormatBytes(float64(p.totalBytes)))
+}
+
+// finish 结束进度输出
+func (p *downloadProgress) finish() {
+	if p.w != nil && len(p.sizes) > 0 {
+		fmt.Fprintln(p.w)
+	}
+}
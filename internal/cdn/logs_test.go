@@ -0,0 +1,216 @@
+package cdn
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ygqygq2/hwcctl/internal/logx"
+)
+
+// gzipData 返回压缩后的测试数据
+func gzipData(t *testing.T, data string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	if _, err := gz.Write([]byte(data)); err != nil {
+		t.Fatalf("压缩失败: %v", err)
+	}
+	gz.Close()
+	return buf.Bytes()
+}
+
+// newLogFileServer 创建提供日志文件下载的测试服务器，支持 Range 请求并记录收到的 Range 头
+func newLogFileServer(t *testing.T, files map[string][]byte) (*httptest.Server, *sync.Map) {
+	t.Helper()
+	ranges := &sync.Map{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, ok := files[strings.TrimPrefix(r.URL.Path, "/")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		rangeHeader := r.Header.Get("Range")
+		ranges.Store(r.URL.Path, rangeHeader)
+		if rangeHeader != "" {
+			var offset int
+			fmt.Sscanf(rangeHeader, "bytes=%d-", &offset)
+			w.Header().Set("Content-Range", fmt.Sprintf("bytes %d-%d/%d", offset, len(data)-1, len(data)))
+			w.WriteHeader(http.StatusPartialContent)
+			w.Write(data[offset:])
+			return
+		}
+		w.Write(data)
+	}))
+	t.Cleanup(server.Close)
+	return server, ranges
+}
+
+func TestListLogs(t *testing.T) {
+	start := time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local)
+	var pages []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		pages = append(pages, query.Get("page_number"))
+		if query.Get("domain_name") != "cdn.example.com" || query.Get("start_time") != fmt.Sprint(start.UnixMilli()) {
+			t.Errorf("请求参数错误: %s", r.URL.RawQuery)
+		}
+		if query.Get("end_time") != fmt.Sprint(start.Add(24*time.Hour).UnixMilli()) {
+			t.Errorf("结束时间应对齐到整点: %s", query.Get("end_time"))
+		}
+		writeJSON(t, w, map[string]interface{}{
+			"total": 2,
+			"logs": []map[string]interface{}{
+				{"domain_name": "cdn.example.com", "name": "01.gz", "size": 2048, "link": "https://obs/01.gz",
+					"start_time": start.Add(time.Hour).UnixMilli(), "end_time": start.Add(2 * time.Hour).UnixMilli()},
+				{"domain_name": "cdn.example.com", "name": "00.gz", "size": 1024, "link": "https://obs/00.gz",
+					"start_time": start.UnixMilli(), "end_time": start.Add(time.Hour).UnixMilli()},
+			},
+		})
+	})
+
+	logs, err := client.ListLogs("cdn.example.com", start.Add(10*time.Minute), start.Add(23*time.Hour+time.Minute))
+	if err != nil {
+		t.Fatalf("查询失败: %v", err)
+	}
+	if len(pages) != 1 {
+		t.Errorf("期望只查询 1 页，实际为 %v", pages)
+	}
+	if len(logs) != 2 || logs[0].Name != "00.gz" || logs[0].Size != 1024 || logs[0].StartTime != "2026-10-15 00:00:00" {
+		t.Errorf("日志列表应按时间排序: %+v", logs)
+	}
+
+	if _, err := client.ListLogs("", start, start.Add(time.Hour)); err == nil {
+		t.Error("域名为空时应返回错误")
+	}
+	if _, err := client.ListLogs("cdn.example.com", start, start.Add(31*24*time.Hour)); err == nil {
+		t.Error("时间跨度超过 30 天时应返回错误")
+	}
+}
+
+func TestDownloadLogsResumeAndSkip(t *testing.T) {
+	content := map[string][]byte{
+		"00.gz": gzipData(t, "line 00\n"),
+		"01.gz": []byte(strings.Repeat("0123456789", 100)),
+		"02.gz": gzipData(t, "line 02\n"),
+	}
+	server, ranges := newLogFileServer(t, content)
+
+	var files []LogFile
+	for _, name := range []string{"00.gz", "01.gz", "02.gz"} {
+		files = append(files, LogFile{Name: name, Size: int64(len(content[name])), Link: server.URL + "/" + name})
+	}
+
+	dir := t.TempDir()
+	// 00.gz 已完整下载，01.gz 下载了一部分
+	os.WriteFile(filepath.Join(dir, "00.gz"), content["00.gz"], 0o644)
+	os.WriteFile(filepath.Join(dir, "01.gz"+partialFileSuffix), content["01.gz"][:300], 0o644)
+
+	var progress bytes.Buffer
+	results, err := DownloadLogs(context.Background(), files, LogDownloadOptions{Dir: dir, Concurrency: 2, Progress: &progress})
+	if err != nil {
+		t.Fatalf("下载失败: %v", err)
+	}
+
+	statuses := []string{results[0].Status, results[1].Status, results[2].Status}
+	if strings.Join(statuses, ",") != "skipped,resumed,downloaded" {
+		t.Errorf("下载状态错误: %v", statuses)
+	}
+	if r, _ := ranges.Load("/01.gz"); r != "bytes=300-" {
+		t.Errorf("应从断点续传，实际 Range 为 %v", r)
+	}
+	if _, ok := ranges.Load("/00.gz"); ok {
+		t.Error("已完整存在的文件不应重新下载")
+	}
+	for name, data := range content {
+		got, err := os.ReadFile(filepath.Join(dir, name))
+		if err != nil || !bytes.Equal(got, data) {
+			t.Errorf("文件 %s 内容错误: %v", name, err)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(dir, "01.gz"+partialFileSuffix)); !os.IsNotExist(err) {
+		t.Error("下载完成后应删除未完成文件")
+	}
+	if !strings.Contains(progress.String(), "3/3 个文件") {
+		t.Errorf("进度输出错误: %q", progress.String())
+	}
+}
+
+func TestDownloadLogsSizeMismatch(t *testing.T) {
+	server, _ := newLogFileServer(t, map[string][]byte{"00.gz": []byte("short")})
+	files := []LogFile{
+		{Name: "00.gz", Size: 100, Link: server.URL + "/00.gz"},
+		{Name: "missing.gz", Size: 10, Link: server.URL + "/missing.gz"},
+	}
+
+	dir := t.TempDir()
+	results, err := DownloadLogs(context.Background(), files, LogDownloadOptions{Dir: dir})
+	if err == nil || !strings.Contains(err.Error(), "2/2 个日志文件下载失败") {
+		t.Fatalf("期望返回汇总错误，实际为 %v", err)
+	}
+	if results[0].Status != LogStatusFailed || !strings.Contains(results[0].Error, "下载不完整") {
+		t.Errorf("大小不一致时应失败: %+v", results[0])
+	}
+	if _, err := os.Stat(filepath.Join(dir, "00.gz")); !os.IsNotExist(err) {
+		t.Error("大小校验失败时不应生成日志文件")
+	}
+	if _, err := os.Stat(filepath.Join(dir, "00.gz"+partialFileSuffix)); err != nil {
+		t.Error("下载不完整时应保留未完成文件以便续传")
+	}
+}
+
+func TestStreamLogs(t *testing.T) {
+	server, _ := newLogFileServer(t, map[string][]byte{
+		"00.gz":  gzipData(t, "line 00\n"),
+		"01.log": []byte("line 01\n"),
+	})
+	files := []LogFile{
+		{Name: "00.gz", Link: server.URL + "/00.gz"},
+		{Name: "01.log", Link: server.URL + "/01.log"},
+	}
+
+	var out bytes.Buffer
+	if err := StreamLogs(context.Background(), files, &out, LogDownloadOptions{}); err != nil {
+		t.Fatalf("输出日志失败: %v", err)
+	}
+	if out.String() != "line 00\nline 01\n" {
+		t.Errorf("日志内容错误: %q", out.String())
+	}
+}
+
+func TestStreamLogsDebugHTTP(t *testing.T) {
+	var logs bytes.Buffer
+	logx.SetOutput(&logs)
+	logx.SetLevel("debug")
+	logx.SetHTTPDebug(true)
+	t.Cleanup(func() {
+		logx.SetOutput(os.Stderr)
+		logx.SetLevel("warn")
+		logx.SetHTTPDebug(false)
+	})
+
+	server, _ := newLogFileServer(t, map[string][]byte{"01.log": []byte("line 01\n")})
+	files := []LogFile{{Name: "01.log", Link: server.URL + "/01.log?Signature=secret-signature"}}
+	if err := StreamLogs(context.Background(), files, io.Discard, LogDownloadOptions{}); err != nil {
+		t.Fatalf("输出日志失败: %v", err)
+	}
+
+	// 下载链接中的签名需要脱敏
+	output := logs.String()
+	if !strings.Contains(output, "method=GET") || !strings.Contains(output, "/01.log") {
+		t.Errorf("应记录日志下载请求: %s", output)
+	}
+	if strings.Contains(output, "secret-signature") {
+		t.Errorf("下载链接中的签名未脱敏: %s", output)
+	}
+}
@@ -0,0 +1,202 @@
+package cdn
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/retry"
+	"gopkg.in/yaml.v3"
+)
+
+// 配置应用结果状态
+const (
+	ApplyStatusSuccess = "success"
+	ApplyStatusFailed  = "failed"
+)
+
+// DomainsFile 多个加速域名的期望配置文件
+type DomainsFile struct {
+	Domains []*DomainConfig `json:"domains" yaml:"domains"`
+}
+
+// PlanOptions 生成和执行变更计划的参数
+type PlanOptions struct {
+	// Concurrency 同时处理的域名数量，小于等于 0 时使用默认值
+	Concurrency int
+	// Retryer 单个域名查询或更新失败时的重试器，为 nil 时不重试
+	Retryer *retry.Retryer
+}
+
+// Plan 多个加速域名的变更计划
+type Plan struct {
+	Domains []*ConfigDiff `json:"domains" yaml:"domains"`
+}
+
+// ChangedDomains 返回存在变更的域名差异
+func (p *Plan) ChangedDomains() []*ConfigDiff {
+	var changed []*ConfigDiff
+	for _, diff := range p.Domains {
+		if diff.HasChanges() {
+			changed = append(changed, diff)
+		}
+	}
+	return changed
+}
+
+// HasChanges 判断计划中是否存在需要更新的域名
+func (p *Plan) HasChanges() bool {
+	return len(p.ChangedDomains()) > 0
+}
+
+// ApplyResult 单个域名的配置更新结果
+type ApplyResult struct {
+	Domain  string `json:"domain" yaml:"domain" table:"域名"`
+	Changes int    `json:"changes" yaml:"changes" table:"变更配置项"`
+	Status  string `json:"status" yaml:"status" table:"状态"`
+	Error   string `json:"error,omitempty" yaml:"error,omitempty" table:"错误"`
+}
+
+// ParseDomainsFile 解析多域名期望配置文件，也兼容只包含单个域名的配置文件
+func ParseDomainsFile(data []byte) ([]*DomainConfig, error) {
+	var file struct {
+		DomainsFile  `yaml:",inline"`
+		DomainConfig `yaml:",inline"`
+	}
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("解析域名配置文件失败: %v", err))
+	}
+
+	configs := file.Domains
+	if len(configs) == 0 {
+		if file.Domain == "" {
+			return nil, hwErrors.NewValidationError("域名配置文件中没有 domains 列表")
+		}
+		configs = []*DomainConfig{&file.DomainConfig}
+	}
+
+	seen := make(map[string]struct{}, len(configs))
+	for i, config := range configs {
+		if config == nil {
+			return nil, hwErrors.NewValidationError(fmt.Sprintf("domains 第 %d 项为空", i+1))
+		}
+		if err := validateDomainConfig(config); err != nil {
+			return nil, err
+		}
+		if _, exists := seen[config.Domain]; exists {
+			return nil, hwErrors.NewValidationError(fmt.Sprintf("域名 %s 重复定义", config.Domain))
+		}
+		seen[config.Domain] = struct{}{}
+	}
+	return configs, nil
+}
+
+// PlanDomainConfigs 查询所有域名的线上配置并与期望配置比较，结果顺序与输入一致
+func (c *Client) PlanDomainConfigs(ctx context.Context, desired []*DomainConfig, opts PlanOptions) (*Plan, error) {
+	plan := &Plan{Domains: make([]*ConfigDiff, len(desired))}
+
+	errs := forEachConcurrently(len(desired), opts.Concurrency, func(i int) error {
+		var live *DomainConfig
+		err := withRetry(ctx, opts.Retryer, func() error {
+			var err error
+			live, err = c.GetDomainConfig(desired[i].Domain)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+
+		diff, err := DiffDomainConfig(live, desired[i])
+		if err != nil {
+			return err
+		}
+		plan.Domains[i] = diff
+		return nil
+	})
+
+	failed := 0
+	var firstErr error
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		failed++
+		logx.Debugf("查询加速域名 %s 的配置失败: %v", desired[i].Domain, err)
+		if firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", desired[i].Domain, err)
+		}
+	}
+	if failed > 0 {
+		return nil, fmt.Errorf("%d/%d 个域名查询配置失败，%w", failed, len(desired), firstErr)
+	}
+	return plan, nil
+}
+
+// ApplyPlan 更新计划中存在变更的域名，部分域名失败时仍返回全部结果和汇总错误
+func (c *Client) ApplyPlan(ctx context.Context, plan *Plan, opts PlanOptions) ([]ApplyResult, error) {
+	changed := plan.ChangedDomains()
+	results := make([]ApplyResult, len(changed))
+
+	errs := forEachConcurrently(len(changed), opts.Concurrency, func(i int) error {
+		diff := changed[i]
+		err := withRetry(ctx, opts.Retryer, func() error {
+			return c.ApplyDomainConfig(diff)
+		})
+
+		results[i] = ApplyResult{Domain: diff.Domain, Changes: len(diff.Changes), Status: ApplyStatusSuccess}
+		if err != nil {
+			results[i].Status = ApplyStatusFailed
+			results[i].Error = err.Error()
+		}
+		return err
+	})
+
+	failed := 0
+	var firstErr error
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		failed++
+		logx.Debugf("更新加速域名 %s 的配置失败: %v", changed[i].Domain, err)
+		if firstErr == nil {
+			firstErr = fmt.Errorf("%s: %w", changed[i].Domain, err)
+		}
+	}
+	if failed > 0 {
+		return results, fmt.Errorf("%d/%d 个域名更新配置失败，%w", failed, len(changed), firstErr)
+	}
+	return results, nil
+}
+
+// forEachConcurrently 以有限并发执行 fn，返回与下标对应的错误
+func forEachConcurrently(n, concurrency int, fn func(i int) error) []error {
+	if concurrency <= 0 {
+		concurrency = DefaultBatchConcurrency
+	}
+
+	errs := make([]error, n)
+	semaphore := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			errs[i] = fn(i)
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+// withRetry 在 retryer 不为 nil 时重试 fn
+func withRetry(ctx context.Context, retryer *retry.Retryer, fn retry.RetryableFunc) error {
+	if retryer == nil {
+		return fn()
+	}
+	return retryer.Do(ctx, fn)
+}
@@ -0,0 +1,182 @@
+package cdn
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestParseDomainsFile(t *testing.T) {
+	configs, err := ParseDomainsFile([]byte(`
+domains:
+  - domain: a.example.com
+    configs:
+      compress:
+        status: "on"
+  - domain: b.example.com
+    configs:
+      referer:
+        type: black
+        value: bad.example.com
+`))
+	if err != nil {
+		t.Fatalf("解析失败: %v", err)
+	}
+	if len(configs) != 2 || configs[0].Domain != "a.example.com" || configs[1].Configs["referer"] == nil {
+		t.Errorf("解析结果错误: %+v", configs)
+	}
+
+	// 兼容单域名配置文件
+	single, err := ParseDomainsFile([]byte("domain: a.example.com\nconfigs:\n  compress:\n    status: \"on\"\n"))
+	if err != nil || len(single) != 1 || single[0].Domain != "a.example.com" {
+		t.Errorf("单域名配置解析错误: %+v, %v", single, err)
+	}
+
+	invalid := []string{
+		"domains: [",
+		"foo: bar",
+		"domains:\n  - domain: a.example.com\n",
+		"domains:\n  - domain: a.example.com\n    configs: {compress: {}}\n  - domain: a.example.com\n    configs: {compress: {}}\n",
+	}
+	for _, data := range invalid {
+		if _, err := ParseDomainsFile([]byte(data)); err == nil {
+			t.Errorf("期望 %q 解析失败", data)
+		}
+	}
+}
+
+// newPlanTestHandler 模拟多个域名的配置查询和更新接口，a.example.com 的压缩为开启状态
+func newPlanTestHandler(t *testing.T, updated *[]string, failDomain string) http.HandlerFunc {
+	var mu sync.Mutex
+	return func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.Split(r.URL.Path, "/")
+		domain := parts[len(parts)-2]
+
+		if r.Method == http.MethodPut {
+			if domain == failDomain {
+				w.WriteHeader(http.StatusBadRequest)
+				writeJSON(t, w, map[string]interface{}{"error": map[string]string{"error_code": "CDN.0001", "error_msg": "bad config"}})
+				return
+			}
+			mu.Lock()
+			*updated = append(*updated, domain)
+			mu.Unlock()
+			writeJSON(t, w, map[string]interface{}{})
+			return
+		}
+
+		status := "off"
+		if domain == "a.example.com" {
+			status = "on"
+		}
+		writeJSON(t, w, map[string]interface{}{"configs": map[string]interface{}{"compress": map[string]interface{}{"status": status}}})
+	}
+}
+
+// planTestConfigs 期望所有域名都开启压缩
+func planTestConfigs(domains ...string) []*DomainConfig {
+	configs := make([]*DomainConfig, 0, len(domains))
+	for _, domain := range domains {
+		configs = append(configs, &DomainConfig{
+			Domain:  domain,
+			Configs: map[string]interface{}{"compress": map[string]interface{}{"status": "on"}},
+		})
+	}
+	return configs
+}
+
+func TestPlanDomainConfigs(t *testing.T) {
+	var updated []string
+	client := newTestClient(t, newPlanTestHandler(t, &updated, ""))
+
+	plan, err := client.PlanDomainConfigs(context.Background(),
+		planTestConfigs("a.example.com", "b.example.com", "c.example.com"), PlanOptions{Concurrency: 2})
+	if err != nil {
+		t.Fatalf("生成计划失败: %v", err)
+	}
+
+	if len(plan.Domains) != 3 || plan.Domains[0].Domain != "a.example.com" || plan.Domains[2].Domain != "c.example.com" {
+		t.Fatalf("计划顺序应与输入一致: %+v", plan.Domains)
+	}
+	changed := plan.ChangedDomains()
+	if !plan.HasChanges() || len(changed) != 2 || changed[0].Domain != "b.example.com" {
+		t.Errorf("期望 b、c 两个域名存在变更，实际为 %+v", changed)
+	}
+}
+
+func TestApplyPlanPartialFailure(t *testing.T) {
+	var updated []string
+	client := newTestClient(t, newPlanTestHandler(t, &updated, "c.example.com"))
+
+	plan, err := client.PlanDomainConfigs(context.Background(),
+		planTestConfigs("a.example.com", "b.example.com", "c.example.com"), PlanOptions{})
+	if err != nil {
+		t.Fatalf("生成计划失败: %v", err)
+	}
+
+	results, err := client.ApplyPlan(context.Background(), plan, PlanOptions{})
+	if err == nil || !strings.Contains(err.Error(), "1/2") {
+		t.Errorf("期望返回部分失败的汇总错误，实际为 %v", err)
+	}
+	if len(results) != 2 || results[0].Status != ApplyStatusSuccess || results[1].Status != ApplyStatusFailed {
+		t.Errorf("更新结果错误: %+v", results)
+	}
+	if len(updated) != 1 || updated[0] != "b.example.com" {
+		t.Errorf("期望只更新 b.example.com，实际为 %v", updated)
+	}
+}
+
+func TestApplyPlanKeepsLiveFields(t *testing.T) {
+	// 模拟接口按配置项整体覆盖写入
+	var mu sync.Mutex
+	live := map[string]interface{}{
+		"compress": map[string]interface{}{"status": "off", "type": "gzip", "file_type": ".js,.css"},
+		"referer":  map[string]interface{}{"type": "white", "value": "a.example.com", "include_empty": true},
+	}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.Method == http.MethodPut {
+			var body struct {
+				Configs map[string]interface{} `json:"configs"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Errorf("解析请求失败: %v", err)
+			}
+			for section, value := range body.Configs {
+				live[section] = value
+			}
+			writeJSON(t, w, map[string]interface{}{})
+			return
+		}
+		writeJSON(t, w, map[string]interface{}{"configs": live})
+	})
+
+	plan, err := client.PlanDomainConfigs(context.Background(), planTestConfigs("a.example.com"), PlanOptions{})
+	if err != nil {
+		t.Fatalf("生成计划失败: %v", err)
+	}
+	if _, err := client.ApplyPlan(context.Background(), plan, PlanOptions{}); err != nil {
+		t.Fatalf("更新失败: %v", err)
+	}
+
+	compress, _ := live["compress"].(map[string]interface{})
+	if compress["status"] != "on" || compress["type"] != "gzip" || compress["file_type"] != ".js,.css" {
+		t.Errorf("更新后应保留期望配置未指定的线上字段，实际为 %v", compress)
+	}
+	if referer, _ := live["referer"].(map[string]interface{}); referer["include_empty"] != true {
+		t.Errorf("未变更的配置项不应被修改，实际为 %v", referer)
+	}
+
+	// 更新后再次比较不应存在差异
+	plan, err = client.PlanDomainConfigs(context.Background(), planTestConfigs("a.example.com"), PlanOptions{})
+	if err != nil {
+		t.Fatalf("生成计划失败: %v", err)
+	}
+	if plan.HasChanges() {
+		t.Errorf("更新后不应再检测到差异，实际为 %+v", plan.ChangedDomains())
+	}
+}
@@ -0,0 +1,422 @@
+package cdn
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2/model"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/utils"
+)
+
+// 统计指标
+const (
+	MetricFlux       = "flux"          // 流量，单位 Byte
+	MetricBandwidth  = "bw"            // 带宽峰值，单位 bit/s
+	MetricReqNum     = "req_num"       // 请求总数
+	MetricHitNum     = "hit_num"       // 命中请求数
+	MetricHitRate    = "hit_rate"      // 请求命中率，由 hit_num / req_num 计算
+	MetricBSFlux     = "bs_flux"       // 回源流量
+	MetricBSBW       = "bs_bw"         // 回源带宽
+	MetricBSNum      = "bs_num"        // 回源请求数
+	MetricBSFailNum  = "bs_fail_num"   // 回源失败数
+	MetricHTTPCode2x = "http_code_2xx" // 2xx 状态码数量
+	MetricHTTPCode3x = "http_code_3xx" // 3xx 状态码数量
+	MetricHTTPCode4x = "http_code_4xx" // 4xx 状态码数量
+	MetricHTTPCode5x = "http_code_5xx" // 5xx 状态码数量
+)
+
+// 统计分组方式
+const (
+	StatsGroupByDomain  = "domain"
+	StatsGroupByRegion  = "region"
+	StatsGroupByCarrier = "carrier"
+)
+
+// 统计时间间隔
+const (
+	StatsInterval5Min = 5 * time.Minute
+	StatsIntervalHour = time.Hour
+	StatsIntervalDay  = 24 * time.Hour
+)
+
+// StatsMetrics 支持查询的统计指标
+var StatsMetrics = []string{
+	MetricFlux, MetricBandwidth, MetricReqNum, MetricHitNum, MetricHitRate,
+	MetricBSFlux, MetricBSBW, MetricBSNum, MetricBSFailNum,
+	MetricHTTPCode2x, MetricHTTPCode3x, MetricHTTPCode4x, MetricHTTPCode5x,
+}
+
+// StatsMetricAliases 指标别名
+var StatsMetricAliases = map[string][]string{
+	"bandwidth":    {MetricBandwidth},
+	"status_codes": {MetricHTTPCode2x, MetricHTTPCode3x, MetricHTTPCode4x, MetricHTTPCode5x},
+}
+
+// StatsGroupBys 支持的分组方式
+var StatsGroupBys = []string{StatsGroupByDomain, StatsGroupByRegion, StatsGroupByCarrier}
+
+// locationMetrics 按区域或运营商分组时支持的指标
+var locationMetrics = []string{
+	MetricFlux, MetricBandwidth, MetricReqNum,
+	MetricHTTPCode2x, MetricHTTPCode3x, MetricHTTPCode4x, MetricHTTPCode5x,
+}
+
+// statsTimeZone 按天统计时接口要求的时区（东八区）
+var statsTimeZone = time.FixedZone("UTC+8", 8*60*60)
+
+// StatsOptions 统计查询条件
+type StatsOptions struct {
+	Domains []string // 域名列表，为空时查询全部域名
+	Metrics []string
+	Start   time.Time
+	End     time.Time
+	// Interval 大于 0 时按该间隔返回明细数据，否则返回汇总数据
+	Interval    time.Duration
+	GroupBy     string
+	ServiceArea string
+}
+
+// StatsRow 一行统计数据，汇总查询时 Time 为空，不分组时 Group 为空
+type StatsRow struct {
+	Time   string             `json:"time,omitempty" yaml:"time,omitempty"`
+	Group  string             `json:"group,omitempty" yaml:"group,omitempty"`
+	Values map[string]float64 `json:"values" yaml:"values"`
+}
+
+// StatsResult 统计查询结果
+type StatsResult struct {
+	Metrics  []string   `json:"metrics" yaml:"metrics"`
+	GroupBy  string     `json:"group_by,omitempty" yaml:"group_by,omitempty"`
+	Start    string     `json:"start_time" yaml:"start_time"`
+	End      string     `json:"end_time" yaml:"end_time"`
+	Interval int64      `json:"interval,omitempty" yaml:"interval,omitempty"`
+	Rows     []StatsRow `json:"rows" yaml:"rows"`
+}
+
+// ExpandStatsMetrics 展开指标别名并去重，校验指标是否支持
+func ExpandStatsMetrics(metrics []string) ([]string, error) {
+	var result []string
+	seen := make(map[string]struct{})
+	for _, metric := range metrics {
+		metric = strings.ToLower(strings.TrimSpace(metric))
+		if metric == "" {
+			continue
+		}
+		expanded, isAlias := StatsMetricAliases[metric]
+		if !isAlias {
+			if !utils.StringSliceContains(StatsMetrics, metric) {
+				return nil, hwErrors.NewValidationError(fmt.Sprintf("不支持的统计指标: %s，支持的指标: %s",
+					metric, strings.Join(StatsMetrics, ", ")))
+			}
+			expanded = []string{metric}
+		}
+		for _, m := range expanded {
+			if _, exists := seen[m]; !exists {
+				seen[m] = struct{}{}
+				result = append(result, m)
+			}
+		}
+	}
+	if len(result) == 0 {
+		return nil, hwErrors.NewValidationError("请至少指定一个统计指标")
+	}
+	return result, nil
+}
+
+// AlignStatsRange 将时间范围对齐到接口要求的时刻点，起始时间向下取整，结束时间向上取整
+// 按天统计时以东八区零点对齐，其余以 5 分钟或 1 小时对齐
+func AlignStatsRange(start, end time.Time, interval time.Duration) (time.Time, time.Time) {
+	step := interval
+	if step <= 0 {
+		step = StatsInterval5Min
+	}
+
+	if step >= StatsIntervalDay {
+		startDay := start.In(statsTimeZone)
+		alignedStart := time.Date(startDay.Year(), startDay.Month(), startDay.Day(), 0, 0, 0, 0, statsTimeZone)
+		endDay := end.In(statsTimeZone)
+		alignedEnd := time.Date(endDay.Year(), endDay.Month(), endDay.Day(), 0, 0, 0, 0, statsTimeZone)
+		if alignedEnd.Before(end) {
+			alignedEnd = alignedEnd.AddDate(0, 0, 1)
+		}
+		return alignedStart, alignedEnd
+	}
+
+	alignedStart := start.Truncate(step)
+	alignedEnd := end.Truncate(step)
+	if alignedEnd.Before(end) {
+		alignedEnd = alignedEnd.Add(step)
+	}
+	return alignedStart, alignedEnd
+}
+
+// GetStats 查询统计数据，每个指标单独请求后按时间和分组合并
+func (c *Client) GetStats(opts StatsOptions) (*StatsResult, error) {
+	if err := validateStatsOptions(opts); err != nil {
+		return nil, err
+	}
+
+	// 命中率由命中数和请求数计算
+	queryMetrics := make([]string, 0, len(opts.Metrics)+2)
+	for _, metric := range opts.Metrics {
+		if metric == MetricHitRate {
+			queryMetrics = appendUnique(queryMetrics, MetricHitNum, MetricReqNum)
+			continue
+		}
+		queryMetrics = appendUnique(queryMetrics, metric)
+	}
+
+	start, end := AlignStatsRange(opts.Start, opts.End, opts.Interval)
+	result := &StatsResult{
+		Metrics:  opts.Metrics,
+		GroupBy:  opts.GroupBy,
+		Start:    start.Local().Format("2006-01-02 15:04:05"),
+		End:      end.Local().Format("2006-01-02 15:04:05"),
+		Interval: int64(opts.Interval / time.Second),
+	}
+
+	rows := newStatsRowSet()
+	for _, metric := range queryMetrics {
+		data, err := c.queryStatsMetric(opts, metric, start, end)
+		if err != nil {
+			return nil, err
+		}
+		for group, values := range data {
+			for i, value := range values {
+				timestamp := ""
+				if opts.Interval > 0 {
+					timestamp = start.Add(time.Duration(i) * opts.Interval).Local().Format("2006-01-02 15:04:05")
+				}
+				rows.set(timestamp, group, metric, value)
+			}
+		}
+	}
+
+	result.Rows = rows.sorted()
+	for i := range result.Rows {
+		values := result.Rows[i].Values
+		if utils.StringSliceContains(opts.Metrics, MetricHitRate) {
+			if values[MetricReqNum] > 0 {
+				values[MetricHitRate] = values[MetricHitNum] / values[MetricReqNum] * 100
+			} else {
+				values[MetricHitRate] = 0
+			}
+		}
+		// 只保留请求的指标
+		for metric := range values {
+			if !utils.StringSliceContains(opts.Metrics, metric) {
+				delete(values, metric)
+			}
+		}
+	}
+	return result, nil
+}
+
+// validateStatsOptions 校验统计查询条件
+func validateStatsOptions(opts StatsOptions) error {
+	if len(opts.Metrics) == 0 {
+		return hwErrors.NewValidationError("请至少指定一个统计指标")
+	}
+	if !opts.End.After(opts.Start) {
+		return hwErrors.NewValidationError("结束时间必须晚于起始时间")
+	}
+	switch opts.Interval {
+	case 0, StatsInterval5Min, StatsIntervalHour, StatsIntervalDay:
+	default:
+		return hwErrors.NewValidationError("统计间隔只支持 5m、1h、1d")
+	}
+	if opts.GroupBy != "" && !utils.StringSliceContains(StatsGroupBys, opts.GroupBy) {
+		return hwErrors.NewValidationError(fmt.Sprintf("不支持的分组方式: %s，支持的方式: %s",
+			opts.GroupBy, strings.Join(StatsGroupBys, ", ")))
+	}
+	if opts.GroupBy == StatsGroupByRegion || opts.GroupBy == StatsGroupByCarrier {
+		for _, metric := range opts.Metrics {
+			if !utils.StringSliceContains(locationMetrics, metric) {
+				return hwErrors.NewValidationError(fmt.Sprintf("按区域或运营商分组时不支持指标 %s，支持的指标: %s",
+					metric, strings.Join(locationMetrics, ", ")))
+			}
+		}
+	}
+	return nil
+}
+
+// queryStatsMetric 查询单个指标，返回 分组 -> 数值列表，汇总查询时列表只有一个元素
+func (c *Client) queryStatsMetric(opts StatsOptions, metric string, start, end time.Time) (map[string][]float64, error) {
+	domainName := "all"
+	if len(opts.Domains) > 0 {
+		domainName = strings.Join(opts.Domains, ",")
+	}
+	enterpriseProjectID := c.enterpriseProjectID()
+
+	var interval *int64
+	if opts.Interval > 0 {
+		seconds := int64(opts.Interval / time.Second)
+		interval = &seconds
+	}
+
+	logx.Debugf("查询统计指标 %s，域名: %s，时间: %s - %s", metric, domainName, start, end)
+
+	var result map[string]interface{}
+	grouped := opts.GroupBy != ""
+	switch opts.GroupBy {
+	case StatsGroupByRegion, StatsGroupByCarrier:
+		action := "location_summary"
+		if opts.Interval > 0 {
+			action = "location_detail"
+		}
+		country := "cn"
+		groupBy := "province"
+		request := &model.ShowDomainLocationStatsRequest{
+			Action:              action,
+			StartTime:           start.UnixMilli(),
+			EndTime:             end.UnixMilli(),
+			DomainName:          domainName,
+			StatType:            metric,
+			Interval:            interval,
+			GroupBy:             &groupBy,
+			EnterpriseProjectId: &enterpriseProjectID,
+		}
+		if opts.GroupBy == StatsGroupByCarrier {
+			groupBy = "isp"
+		} else {
+			request.Country = &country
+		}
+		response, err := c.cdnClient.ShowDomainLocationStats(request)
+		if err != nil {
+			logx.Debugf("查询区域统计数据失败: %v", err)
+			return nil, hwErrors.FromSDKError(err)
+		}
+		result = response.Result
+	default:
+		action := "summary"
+		if opts.Interval > 0 {
+			action = "detail"
+		}
+		request := &model.ShowDomainStatsRequest{
+			Action:              action,
+			StartTime:           start.UnixMilli(),
+			EndTime:             end.UnixMilli(),
+			DomainName:          domainName,
+			StatType:            metric,
+			Interval:            interval,
+			EnterpriseProjectId: &enterpriseProjectID,
+		}
+		if grouped {
+			request.GroupBy = &opts.GroupBy
+		}
+		if opts.ServiceArea != "" {
+			request.ServiceArea = &opts.ServiceArea
+		}
+		response, err := c.cdnClient.ShowDomainStats(request)
+		if err != nil {
+			logx.Debugf("查询统计数据失败: %v", err)
+			return nil, hwErrors.FromSDKError(err)
+		}
+		result = response.Result
+	}
+
+	return extractStatsMetric(result, metric, grouped), nil
+}
+
+// extractStatsMetric 从接口返回的 result 中提取指标数值
+// 不分组时为 {metric: 数值或数组}，分组时为 {分组: {metric: 数值或数组}}
+func extractStatsMetric(result map[string]interface{}, metric string, grouped bool) map[string][]float64 {
+	data := make(map[string][]float64)
+	if !grouped {
+		if values, ok := toFloatSlice(result[metric]); ok {
+			data[""] = values
+		}
+		return data
+	}
+
+	for group, raw := range result {
+		fields, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if values, ok := toFloatSlice(fields[metric]); ok {
+			data[group] = values
+		}
+	}
+	return data
+}
+
+// toFloatSlice 将数值或数值数组转换为 float64 切片，SDK 解析响应时数值为 json.Number
+func toFloatSlice(v interface{}) ([]float64, bool) {
+	switch value := v.(type) {
+	case json.Number:
+		f, err := value.Float64()
+		if err != nil {
+			return nil, false
+		}
+		return []float64{f}, true
+	case float64:
+		return []float64{value}, true
+	case int:
+		return []float64{float64(value)}, true
+	case int64:
+		return []float64{float64(value)}, true
+	case []interface{}:
+		values := make([]float64, 0, len(value))
+		for _, item := range value {
+			f, ok := toFloatSlice(item)
+			if !ok || len(f) != 1 {
+				values = append(values, 0)
+				continue
+			}
+			values = append(values, f[0])
+		}
+		return values, true
+	default:
+		return nil, false
+	}
+}
+
+// appendUnique 追加不存在的元素
+func appendUnique(slice []string, items ...string) []string {
+	for _, item := range items {
+		if !utils.StringSliceContains(slice, item) {
+			slice = append(slice, item)
+		}
+	}
+	return slice
+}
+
+// statsRowSet 按 时间+分组 合并不同指标的数据
+type statsRowSet struct {
+	rows map[[2]string]*StatsRow
+}
+
+func newStatsRowSet() *statsRowSet {
+	return &statsRowSet{rows: make(map[[2]string]*StatsRow)}
+}
+
+func (s *statsRowSet) set(timestamp, group, metric string, value float64) {
+	key := [2]string{timestamp, group}
+	row, exists := s.rows[key]
+	if !exists {
+		row = &StatsRow{Time: timestamp, Group: group, Values: make(map[string]float64)}
+		s.rows[key] = row
+	}
+	row.Values[metric] = value
+}
+
+// sorted 按时间和分组排序返回
+func (s *statsRowSet) sorted() []StatsRow {
+	rows := make([]StatsRow, 0, len(s.rows))
+	for _, row := range s.rows {
+		rows = append(rows, *row)
+	}
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].Time != rows[j].Time {
+			return rows[i].Time < rows[j].Time
+		}
+		return rows[i].Group < rows[j].Group
+	})
+	return rows
+}
@@ -0,0 +1,158 @@
+package cdn
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExpandStatsMetrics(t *testing.T) {
+	metrics, err := ExpandStatsMetrics([]string{"flux", "bandwidth", "BW", "status_codes"})
+	if err != nil {
+		t.Fatalf("展开失败: %v", err)
+	}
+	expected := []string{"flux", "bw", "http_code_2xx", "http_code_3xx", "http_code_4xx", "http_code_5xx"}
+	if strings.Join(metrics, ",") != strings.Join(expected, ",") {
+		t.Errorf("期望 %v，实际为 %v", expected, metrics)
+	}
+
+	if _, err := ExpandStatsMetrics([]string{"unknown"}); err == nil {
+		t.Error("不支持的指标应返回错误")
+	}
+	if _, err := ExpandStatsMetrics([]string{" "}); err == nil {
+		t.Error("空指标应返回错误")
+	}
+}
+
+func TestAlignStatsRange(t *testing.T) {
+	start := time.Date(2026, 10, 15, 10, 7, 30, 0, time.UTC)
+	end := time.Date(2026, 10, 15, 12, 1, 0, 0, time.UTC)
+
+	s, e := AlignStatsRange(start, end, 0)
+	if !s.Equal(time.Date(2026, 10, 15, 10, 5, 0, 0, time.UTC)) || !e.Equal(time.Date(2026, 10, 15, 12, 5, 0, 0, time.UTC)) {
+		t.Errorf("5 分钟对齐错误: %s - %s", s, e)
+	}
+
+	s, e = AlignStatsRange(start, end, StatsIntervalHour)
+	if !s.Equal(time.Date(2026, 10, 15, 10, 0, 0, 0, time.UTC)) || !e.Equal(time.Date(2026, 10, 15, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("小时对齐错误: %s - %s", s, e)
+	}
+
+	// 按天统计以东八区零点对齐：UTC 10:07 为东八区 18:07
+	s, e = AlignStatsRange(start, end, StatsIntervalDay)
+	if !s.Equal(time.Date(2026, 10, 14, 16, 0, 0, 0, time.UTC)) || !e.Equal(time.Date(2026, 10, 15, 16, 0, 0, 0, time.UTC)) {
+		t.Errorf("按天对齐错误: %s - %s", s, e)
+	}
+}
+
+func TestGetStatsSummaryGroupedByDomain(t *testing.T) {
+	var statTypes []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		statType := query.Get("stat_type")
+		statTypes = append(statTypes, statType)
+		if query.Get("action") != "summary" || query.Get("group_by") != "domain" {
+			t.Errorf("请求参数错误: %s", r.URL.RawQuery)
+		}
+		if query.Get("domain_name") != "a.example.com,b.example.com" {
+			t.Errorf("域名参数错误: %s", query.Get("domain_name"))
+		}
+
+		values := map[string]map[string]float64{
+			"flux":    {"a.example.com": 2048, "b.example.com": 1024},
+			"req_num": {"a.example.com": 100, "b.example.com": 50},
+			"hit_num": {"a.example.com": 90, "b.example.com": 10},
+		}[statType]
+		result := map[string]interface{}{}
+		for domain, value := range values {
+			result[domain] = map[string]interface{}{statType: value}
+		}
+		writeJSON(t, w, map[string]interface{}{"result": result})
+	})
+
+	now := time.Now()
+	stats, err := client.GetStats(StatsOptions{
+		Domains: []string{"a.example.com", "b.example.com"},
+		Metrics: []string{MetricFlux, MetricHitRate},
+		Start:   now.Add(-24 * time.Hour),
+		End:     now,
+		GroupBy: StatsGroupByDomain,
+	})
+	if err != nil {
+		t.Fatalf("查询失败: %v", err)
+	}
+
+	if strings.Join(statTypes, ",") != "flux,hit_num,req_num" {
+		t.Errorf("命中率应通过 hit_num 和 req_num 计算，实际请求: %v", statTypes)
+	}
+	if len(stats.Rows) != 2 || stats.Rows[0].Group != "a.example.com" {
+		t.Fatalf("统计结果错误: %+v", stats.Rows)
+	}
+	a := stats.Rows[0].Values
+	if a[MetricFlux] != 2048 || a[MetricHitRate] != 90 || len(a) != 2 {
+		t.Errorf("a.example.com 统计错误: %v", a)
+	}
+}
+
+func TestGetStatsDetailByCarrier(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if !strings.HasSuffix(r.URL.Path, "/domain-location-stats") {
+			t.Errorf("请求路径错误: %s", r.URL.Path)
+		}
+		if query.Get("action") != "location_detail" || query.Get("group_by") != "isp" || query.Get("interval") != "3600" {
+			t.Errorf("请求参数错误: %s", r.URL.RawQuery)
+		}
+		writeJSON(t, w, map[string]interface{}{
+			"group_by": "isp",
+			"result": map[string]interface{}{
+				"CMCC":  map[string]interface{}{"flux": []interface{}{1, 2}},
+				"CUCC":  map[string]interface{}{"flux": []interface{}{3, 4}},
+				"other": "ignored",
+			},
+		})
+	})
+
+	start := time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local)
+	stats, err := client.GetStats(StatsOptions{
+		Metrics:  []string{MetricFlux},
+		Start:    start,
+		End:      start.Add(2 * time.Hour),
+		Interval: StatsIntervalHour,
+		GroupBy:  StatsGroupByCarrier,
+	})
+	if err != nil {
+		t.Fatalf("查询失败: %v", err)
+	}
+
+	if len(stats.Rows) != 4 || stats.Interval != 3600 {
+		t.Fatalf("期望 4 行明细数据，实际为 %+v", stats.Rows)
+	}
+	first, last := stats.Rows[0], stats.Rows[3]
+	if first.Time != "2026-10-15 00:00:00" || first.Group != "CMCC" || first.Values[MetricFlux] != 1 {
+		t.Errorf("第一行数据错误: %+v", first)
+	}
+	if last.Time != "2026-10-15 01:00:00" || last.Group != "CUCC" || last.Values[MetricFlux] != 4 {
+		t.Errorf("最后一行数据错误: %+v", last)
+	}
+}
+
+func TestGetStatsValidation(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("参数无效时不应发送请求")
+	})
+	now := time.Now()
+
+	tests := []StatsOptions{
+		{Metrics: []string{MetricFlux}, Start: now, End: now.Add(-time.Hour)},
+		{Metrics: []string{MetricFlux}, Start: now.Add(-time.Hour), End: now, Interval: 2 * time.Minute},
+		{Metrics: []string{MetricHitRate}, Start: now.Add(-time.Hour), End: now, GroupBy: StatsGroupByRegion},
+		{Metrics: []string{MetricFlux}, Start: now.Add(-time.Hour), End: now, GroupBy: "city"},
+	}
+	for _, opts := range tests {
+		if _, err := client.GetStats(opts); err == nil {
+			t.Errorf("期望参数 %+v 校验失败", opts)
+		}
+	}
+}
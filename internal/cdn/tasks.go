@@ -0,0 +1,327 @@
+package cdn
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2/model"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+)
+
+// 任务详情分页参数
+const (
+	// DefaultTaskDetailsPageSize 查询任务详情时的默认每页 URL 数量
+	DefaultTaskDetailsPageSize = 100
+	// MaxTaskDetailsPageSize 任务详情接口允许的最大每页数量
+	MaxTaskDetailsPageSize = 10000
+	// maxTaskDetailsPageNumber 任务详情接口允许的最大页码
+	maxTaskDetailsPageNumber = 65535
+)
+
+// 历史任务列表分页参数
+const (
+	// DefaultTaskListPageSize 查询历史任务时的默认每页数量
+	DefaultTaskListPageSize = 30
+	// MaxTaskListPageSize 历史任务接口允许的最大每页数量
+	MaxTaskListPageSize = 10000
+)
+
+// TaskTypes 历史任务支持的任务类型过滤值
+var TaskTypes = []string{"refresh", "preload"}
+
+// TaskStatuses 历史任务支持的任务状态过滤值
+var TaskStatuses = []string{"processing", "done"}
+
+// FileTypes 历史任务支持的文件类型过滤值
+var FileTypes = []string{"file", "directory"}
+
+// TaskListOptions 历史任务查询条件
+type TaskListOptions struct {
+	StartTime  time.Time
+	EndTime    time.Time
+	TaskType   string // refresh 或 preload，为空表示不过滤
+	Status     string // processing 或 done，为空表示不过滤
+	FileType   string // file 或 directory，为空表示不过滤
+	PageSize   int
+	PageNumber int
+	All        bool // 为 true 时从 PageNumber 开始自动翻页获取全部任务
+}
+
+// TaskList 历史任务查询结果
+type TaskList struct {
+	Total int    `json:"total"`
+	Tasks []Task `json:"tasks"`
+}
+
+// URLStatuses 任务详情中 URL 支持的状态过滤值
+var URLStatuses = []string{"processing", "succeed", "failed", "waiting", "refreshing", "preheating"}
+
+// TaskURL 任务中单个 URL 的执行详情
+type TaskURL struct {
+	URL        string `json:"url" table:"URL"`
+	Status     string `json:"status" table:"状态"`
+	CreatedAt  string `json:"created_at" table:"创建时间"`
+	FailReason string `json:"fail_reason,omitempty" table:"失败原因"`
+}
+
+// TaskDetails 任务及其每个 URL 的执行详情
+type TaskDetails struct {
+	Task
+	URLs []TaskURL `json:"urls"`
+}
+
+// GetTaskDetails 查询任务中每个 URL 的执行状态，自动翻页获取全部 URL
+// status 非空时只返回该状态的 URL，pageSize 小于等于 0 时使用默认值
+func (c *Client) GetTaskDetails(taskID, status string, pageSize int) (*TaskDetails, error) {
+	logx.Debugf("查询任务详情，任务ID: %s, 状态过滤: %s", taskID, status)
+
+	if pageSize <= 0 {
+		pageSize = DefaultTaskDetailsPageSize
+	}
+	if pageSize > MaxTaskDetailsPageSize {
+		pageSize = MaxTaskDetailsPageSize
+	}
+
+	enterpriseProjectID := c.enterpriseProjectID()
+	size := int32(pageSize)
+
+	var details *TaskDetails
+	for page := int32(1); page <= maxTaskDetailsPageNumber; page++ {
+		pageNumber := page
+		request := &model.ShowHistoryTaskDetailsRequest{
+			EnterpriseProjectId: &enterpriseProjectID,
+			HistoryTasksId:      taskID,
+			PageSize:            &size,
+			PageNumber:          &pageNumber,
+		}
+		if status != "" {
+			request.Status = &status
+		}
+
+		response, err := c.cdnClient.ShowHistoryTaskDetails(request)
+		if err != nil {
+			logx.Debugf("查询任务详情失败: %v", err)
+			return nil, hwErrors.FromSDKError(err)
+		}
+
+		if details == nil {
+			details = convertToTaskDetails(response)
+		}
+
+		urls := []model.UrlObject{}
+		if response.Urls != nil {
+			urls = *response.Urls
+		}
+		for _, u := range urls {
+			details.URLs = append(details.URLs, convertToTaskURL(&u))
+		}
+
+		logx.Debugf("任务详情第 %d 页返回 %d 个 URL", page, len(urls))
+		if len(urls) < pageSize {
+			break
+		}
+	}
+
+	if details == nil || details.ID == "" {
+		return nil, hwErrors.NewNotFoundError(fmt.Sprintf("任务 %s", taskID))
+	}
+
+	return details, nil
+}
+
+// ListTasks 查询历史刷新/预热任务
+func (c *Client) ListTasks(opts TaskListOptions) (*TaskList, error) {
+	logx.Debugf("查询历史任务，条件: %+v", opts)
+
+	request, err := buildHistoryTasksRequest(opts)
+	if err != nil {
+		return nil, err
+	}
+	enterpriseProjectID := c.enterpriseProjectID()
+	request.EnterpriseProjectId = &enterpriseProjectID
+
+	pageSize := int(*request.PageSize)
+	result := &TaskList{Tasks: []Task{}}
+	for page := *request.PageNumber; page <= maxTaskDetailsPageNumber; page++ {
+		pageNumber := page
+		request.PageNumber = &pageNumber
+
+		response, err := c.cdnClient.ShowHistoryTasks(request)
+		if err != nil {
+			logx.Debugf("查询历史任务失败: %v", err)
+			return nil, hwErrors.FromSDKError(err)
+		}
+
+		result.Total = getIntValue(response.Total)
+		tasks := []model.TasksObject{}
+		if response.Tasks != nil {
+			tasks = *response.Tasks
+		}
+		for i := range tasks {
+			result.Tasks = append(result.Tasks, *convertToTask(&tasks[i]))
+		}
+
+		logx.Debugf("历史任务第 %d 页返回 %d 个任务，共 %d 个", page, len(tasks), result.Total)
+		if !opts.All || len(tasks) < pageSize || len(result.Tasks) >= result.Total {
+			break
+		}
+	}
+
+	return result, nil
+}
+
+// buildHistoryTasksRequest 根据查询条件构建历史任务请求
+func buildHistoryTasksRequest(opts TaskListOptions) (*model.ShowHistoryTasksRequest, error) {
+	request := &model.ShowHistoryTasksRequest{}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = DefaultTaskListPageSize
+	}
+	if pageSize > MaxTaskListPageSize {
+		pageSize = MaxTaskListPageSize
+	}
+	pageNumber := opts.PageNumber
+	if pageNumber <= 0 {
+		pageNumber = 1
+	}
+	size := int32(pageSize)
+	number := int32(pageNumber)
+	request.PageSize = &size
+	request.PageNumber = &number
+
+	if !opts.StartTime.IsZero() {
+		startDate := opts.StartTime.UnixMilli()
+		request.StartDate = &startDate
+	}
+	if !opts.EndTime.IsZero() {
+		endDate := opts.EndTime.UnixMilli()
+		request.EndDate = &endDate
+	}
+
+	switch opts.TaskType {
+	case "":
+	case "refresh":
+		taskType := model.GetShowHistoryTasksRequestTaskTypeEnum().REFRESH
+		request.TaskType = &taskType
+	case "preload", "preheating":
+		taskType := model.GetShowHistoryTasksRequestTaskTypeEnum().PREHEATING
+		request.TaskType = &taskType
+	default:
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("不支持的任务类型: %s，支持的类型: refresh, preload", opts.TaskType))
+	}
+
+	switch opts.Status {
+	case "":
+	case "processing", "task_inprocess":
+		status := model.GetShowHistoryTasksRequestStatusEnum().TASK_INPROCESS
+		request.Status = &status
+	case "done", "task_done":
+		status := model.GetShowHistoryTasksRequestStatusEnum().TASK_DONE
+		request.Status = &status
+	default:
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("不支持的任务状态: %s，支持的状态: processing, done", opts.Status))
+	}
+
+	switch opts.FileType {
+	case "":
+	case "file":
+		fileType := model.GetShowHistoryTasksRequestFileTypeEnum().FILE
+		request.FileType = &fileType
+	case "directory":
+		fileType := model.GetShowHistoryTasksRequestFileTypeEnum().DIRECTORY
+		request.FileType = &fileType
+	default:
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("不支持的文件类型: %s，支持的类型: file, directory", opts.FileType))
+	}
+
+	return request, nil
+}
+
+// enterpriseProjectID 返回请求使用的企业项目ID，未配置时使用默认企业项目
+func (c *Client) enterpriseProjectID() string {
+	if c.creds != nil && c.creds.EnterpriseProjectID != "" {
+		return c.creds.EnterpriseProjectID
+	}
+	return "0"
+}
+
+// convertToTaskDetails 转换任务详情响应的汇总信息
+func convertToTaskDetails(resp *model.ShowHistoryTaskDetailsResponse) *TaskDetails {
+	details := &TaskDetails{
+		Task: Task{
+			ID:       getStringValue(resp.Id),
+			FileType: getStringValue(resp.FileType),
+			Succeed:  getIntValue(resp.Succeed),
+			Failed:   getIntValue(resp.Failed),
+			Total:    getIntValue(resp.Total),
+		},
+		URLs: []TaskURL{},
+	}
+
+	if resp.TaskType != nil {
+		switch *resp.TaskType {
+		case "refresh", "REFRESH":
+			details.Type = "refresh"
+		case "preheating", "PREHEATING":
+			details.Type = "preload"
+		default:
+			details.Type = *resp.TaskType
+		}
+	}
+	if resp.Status != nil {
+		details.Status = convertTaskStatus(*resp.Status)
+	}
+	if resp.CreateTime != nil {
+		details.CreatedAt = formatMillis(*resp.CreateTime)
+	}
+	if resp.Total != nil {
+		details.Progress = calculateProgress(*resp.Total, details.Succeed, details.Failed)
+	}
+
+	return details
+}
+
+// convertToTaskURL 转换单个 URL 的执行详情
+func convertToTaskURL(u *model.UrlObject) TaskURL {
+	taskURL := TaskURL{
+		URL:    getStringValue(u.Url),
+		Status: convertURLStatus(getStringValue(u.Status)),
+	}
+	if u.CreateTime != nil {
+		taskURL.CreatedAt = formatMillis(*u.CreateTime)
+	}
+
+	// 失败时返回失败分类和描述
+	switch {
+	case u.FailClassify != nil && u.FailDesc != nil:
+		taskURL.FailReason = fmt.Sprintf("%s: %s", *u.FailClassify, *u.FailDesc)
+	case u.FailClassify != nil:
+		taskURL.FailReason = *u.FailClassify
+	case u.FailDesc != nil:
+		taskURL.FailReason = *u.FailDesc
+	}
+
+	return taskURL
+}
+
+// convertURLStatus 转换 URL 状态为展示值
+func convertURLStatus(status string) string {
+	switch status {
+	case "processing":
+		return "处理中"
+	case "succeed":
+		return "成功"
+	case "failed":
+		return "失败"
+	case "waiting":
+		return "等待中"
+	case "refreshing":
+		return "刷新中"
+	case "preheating":
+		return "预热中"
+	default:
+		return status
+	}
+}
@@ -0,0 +1,283 @@
+package cdn
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ygqygq2/hwcctl/internal/auth"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+)
+
+// newTestClient 创建指向本地 mock 服务的 CDN 客户端
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	session := auth.NewSessionFromConfig(&auth.Config{
+		AccessKey:           "test-ak",
+		SecretKey:           "test-sk",
+		Region:              "cn-north-1",
+		DomainID:            "test-domain-id",
+		EnterpriseProjectID: "0",
+		Endpoints:           map[string]string{auth.ServiceCDN: server.URL, auth.ServiceIAM: server.URL},
+	})
+	client, err := NewClient(session)
+	if err != nil {
+		t.Fatalf("创建测试客户端失败: %v", err)
+	}
+	return client
+}
+
+// writeJSON 输出 JSON 响应
+func writeJSON(t *testing.T, w http.ResponseWriter, v interface{}) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Errorf("写入响应失败: %v", err)
+	}
+}
+
+func TestGetTaskDetailsPagination(t *testing.T) {
+	allURLs := []map[string]interface{}{
+		{"url": "https://example.com/a.js", "status": "succeed", "create_time": 1700000000000},
+		{"url": "https://example.com/b.js", "status": "failed", "create_time": 1700000000000,
+			"fail_classify": "ORIGIN_ERROR", "fail_desc": "origin 404"},
+		{"url": "https://example.com/c.js", "status": "succeed", "create_time": 1700000000000},
+	}
+
+	var pages []string
+	var statuses []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/historytasks/task-1/detail") {
+			t.Errorf("请求路径错误: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		pages = append(pages, query.Get("page_number"))
+		statuses = append(statuses, query.Get("status"))
+
+		pageSize, _ := strconv.Atoi(query.Get("page_size"))
+		page, _ := strconv.Atoi(query.Get("page_number"))
+		start := (page - 1) * pageSize
+		end := start + pageSize
+		if end > len(allURLs) {
+			end = len(allURLs)
+		}
+
+		writeJSON(t, w, map[string]interface{}{
+			"id":          "task-1",
+			"task_type":   "refresh",
+			"status":      "task_done",
+			"create_time": 1700000000000,
+			"processing":  0,
+			"succeed":     2,
+			"failed":      1,
+			"total":       3,
+			"urls":        allURLs[start:end],
+		})
+	})
+
+	details, err := client.GetTaskDetails("task-1", "", 2)
+	if err != nil {
+		t.Fatalf("查询任务详情失败: %v", err)
+	}
+
+	if strings.Join(pages, ",") != "1,2" {
+		t.Errorf("期望请求第 1、2 页，实际为 %v", pages)
+	}
+	if statuses[0] != "" {
+		t.Errorf("未指定状态时不应传递 status 参数，实际为 %q", statuses[0])
+	}
+	if details.ID != "task-1" || details.Type != "refresh" || details.Status != TaskStatusDone {
+		t.Errorf("任务汇总信息错误: %+v", details.Task)
+	}
+	if details.Total != 3 || details.Failed != 1 || details.Progress != 100 {
+		t.Errorf("任务统计错误: total=%d failed=%d progress=%d", details.Total, details.Failed, details.Progress)
+	}
+	if len(details.URLs) != 3 {
+		t.Fatalf("期望返回 3 个 URL，实际为 %d", len(details.URLs))
+	}
+	if details.URLs[1].Status != "失败" || details.URLs[1].FailReason != "ORIGIN_ERROR: origin 404" {
+		t.Errorf("失败 URL 详情错误: %+v", details.URLs[1])
+	}
+}
+
+func TestGetTaskDetailsStatusFilter(t *testing.T) {
+	var status string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		status = r.URL.Query().Get("status")
+		writeJSON(t, w, map[string]interface{}{
+			"id":     "task-2",
+			"status": "task_failed",
+			"urls":   []map[string]interface{}{},
+		})
+	})
+
+	details, err := client.GetTaskDetails("task-2", "failed", 0)
+	if err != nil {
+		t.Fatalf("查询任务详情失败: %v", err)
+	}
+	if status != "failed" {
+		t.Errorf("期望传递 status=failed，实际为 %q", status)
+	}
+	if details.Status != TaskStatusFailed || len(details.URLs) != 0 {
+		t.Errorf("任务详情错误: %+v", details)
+	}
+}
+
+func TestGetTaskStatusByID(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/historytasks/task-100/detail") {
+			t.Errorf("应按任务ID查询任务详情，实际请求: %s", r.URL.Path)
+		}
+		if size := r.URL.Query().Get("page_size"); size != "1" {
+			t.Errorf("查询任务状态只需一个 URL，实际 page_size=%s", size)
+		}
+		writeJSON(t, w, map[string]interface{}{
+			"id":        "task-100",
+			"task_type": "preheating",
+			"status":    "task_inprocess",
+			"succeed":   30,
+			"failed":    0,
+			"total":     60,
+			"urls":      []map[string]interface{}{{"url": "https://example.com/a.js", "status": "succeed"}},
+		})
+	})
+
+	task, err := client.GetTaskStatus("task-100")
+	if err != nil {
+		t.Fatalf("查询任务状态失败: %v", err)
+	}
+	if task.ID != "task-100" || task.Type != "preload" || task.Status != TaskStatusProcessing || task.Progress != 50 {
+		t.Errorf("任务状态错误: %+v", task)
+	}
+}
+
+func TestGetTaskStatusNotFound(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, map[string]interface{}{})
+	})
+
+	_, err := client.GetTaskStatus("task-404")
+	if hwErrors.ExitCode(err) != hwErrors.ExitCodeNotFound {
+		t.Errorf("任务不存在时应返回 NotFound 错误，实际为 %v", err)
+	}
+}
+
+func TestConvertURLStatus(t *testing.T) {
+	for _, status := range URLStatuses {
+		if convertURLStatus(status) == status {
+			t.Errorf("状态 %s 应该被转换为展示值", status)
+		}
+	}
+	if convertURLStatus("unknown") != "unknown" {
+		t.Error("未知状态应该原样返回")
+	}
+}
+
+func TestListTasksAllPages(t *testing.T) {
+	var queries []url.Values
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasSuffix(r.URL.Path, "/historytasks") {
+			t.Errorf("请求路径错误: %s", r.URL.Path)
+		}
+		query := r.URL.Query()
+		queries = append(queries, query)
+
+		page, _ := strconv.Atoi(query.Get("page_number"))
+		tasks := []map[string]interface{}{
+			{"id": fmt.Sprintf("task-%d-1", page), "task_type": "refresh", "status": "task_done",
+				"processing": 0, "succeed": 2, "failed": 0, "total": 2, "file_type": "file", "create_time": 1700000000000},
+		}
+		if page == 1 {
+			tasks = append(tasks, map[string]interface{}{
+				"id": "task-1-2", "task_type": "preheating", "status": "task_failed",
+				"processing": 0, "succeed": 0, "failed": 1, "total": 1, "file_type": "file", "create_time": 1700000000000,
+			})
+		}
+		writeJSON(t, w, map[string]interface{}{"total": 3, "tasks": tasks})
+	})
+
+	start := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2026, 10, 2, 0, 0, 0, 0, time.UTC)
+	list, err := client.ListTasks(TaskListOptions{
+		StartTime: start,
+		EndTime:   end,
+		TaskType:  "refresh",
+		Status:    "done",
+		FileType:  "file",
+		PageSize:  2,
+		All:       true,
+	})
+	if err != nil {
+		t.Fatalf("查询历史任务失败: %v", err)
+	}
+
+	if len(queries) != 2 {
+		t.Fatalf("期望自动翻页请求 2 次，实际 %d 次", len(queries))
+	}
+	first := queries[0]
+	if first.Get("task_type") != "refresh" || first.Get("status") != "task_done" || first.Get("file_type") != "file" {
+		t.Errorf("过滤参数错误: %v", first)
+	}
+	if first.Get("start_date") != strconv.FormatInt(start.UnixMilli(), 10) ||
+		first.Get("end_date") != strconv.FormatInt(end.UnixMilli(), 10) {
+		t.Errorf("时间参数错误: %v", first)
+	}
+	if list.Total != 3 || len(list.Tasks) != 3 {
+		t.Fatalf("期望返回 3 个任务，实际 total=%d len=%d", list.Total, len(list.Tasks))
+	}
+	if list.Tasks[1].Type != "preload" || list.Tasks[1].Status != TaskStatusFailed || list.Tasks[1].Failed != 1 {
+		t.Errorf("任务转换错误: %+v", list.Tasks[1])
+	}
+}
+
+func TestListTasksSinglePage(t *testing.T) {
+	calls := 0
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if r.URL.Query().Get("page_number") != "3" {
+			t.Errorf("期望请求第 3 页，实际为 %s", r.URL.Query().Get("page_number"))
+		}
+		writeJSON(t, w, map[string]interface{}{
+			"total": 100,
+			"tasks": []map[string]interface{}{{"id": "a"}, {"id": "b"}},
+		})
+	})
+
+	list, err := client.ListTasks(TaskListOptions{PageSize: 2, PageNumber: 3})
+	if err != nil {
+		t.Fatalf("查询历史任务失败: %v", err)
+	}
+	if calls != 1 || len(list.Tasks) != 2 {
+		t.Errorf("未指定 All 时只应查询一页，实际请求 %d 次，返回 %d 个任务", calls, len(list.Tasks))
+	}
+}
+
+func TestBuildHistoryTasksRequestValidation(t *testing.T) {
+	invalid := []TaskListOptions{
+		{TaskType: "purge"},
+		{Status: "failed"},
+		{FileType: "dir"},
+	}
+	for _, opts := range invalid {
+		if _, err := buildHistoryTasksRequest(opts); err == nil {
+			t.Errorf("期望条件 %+v 返回错误", opts)
+		}
+	}
+
+	request, err := buildHistoryTasksRequest(TaskListOptions{PageSize: MaxTaskListPageSize + 1})
+	if err != nil {
+		t.Fatalf("构建请求失败: %v", err)
+	}
+	if *request.PageSize != MaxTaskListPageSize || *request.PageNumber != 1 {
+		t.Errorf("分页参数应被规范化，实际 page_size=%d page_number=%d", *request.PageSize, *request.PageNumber)
+	}
+}
@@ -0,0 +1,165 @@
+package cdn
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/services/cdn/v2/model"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/utils"
+)
+
+// TOP 排行类型
+const (
+	TopKindURLs     = "urls"
+	TopKindReferers = "referers"
+	TopKindIPs      = "ips"
+)
+
+// DefaultTopLimit 默认返回的排行数量
+const DefaultTopLimit = 50
+
+// TopKinds 支持的排行类型
+var TopKinds = []string{TopKindURLs, TopKindReferers, TopKindIPs}
+
+// TopMetrics 排行支持的统计指标
+var TopMetrics = []string{MetricFlux, MetricReqNum}
+
+// TopOptions TOP 排行查询条件
+type TopOptions struct {
+	Kind    string
+	Domains []string // 域名列表，为空时查询全部域名
+	Metric  string   // 排序指标：flux 或 req_num
+	Start   time.Time
+	End     time.Time
+	// Limit 返回的最大条数，小于等于 0 时返回接口的全部结果
+	Limit       int
+	ServiceArea string
+}
+
+// TopItem 排行中的一项
+type TopItem struct {
+	Rank  int    `json:"rank" yaml:"rank"`
+	Name  string `json:"name" yaml:"name"`
+	Value int64  `json:"value" yaml:"value"`
+}
+
+// TopResult TOP 排行查询结果
+type TopResult struct {
+	Kind   string    `json:"kind" yaml:"kind"`
+	Metric string    `json:"metric" yaml:"metric"`
+	Start  string    `json:"start_time" yaml:"start_time"`
+	End    string    `json:"end_time" yaml:"end_time"`
+	Items  []TopItem `json:"items" yaml:"items"`
+}
+
+// GetTop 查询 TOP URL、Referer 或客户端 IP 排行，结果按指标值从大到小排序
+// TOP URL 接口只接受东八区零点的时间戳，时间范围按天对齐；其余排行按整点对齐
+func (c *Client) GetTop(opts TopOptions) (*TopResult, error) {
+	if !utils.StringSliceContains(TopKinds, opts.Kind) {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("不支持的排行类型: %s，支持的类型: %s",
+			opts.Kind, strings.Join(TopKinds, ", ")))
+	}
+	if !utils.StringSliceContains(TopMetrics, opts.Metric) {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("排行不支持指标 %s，支持的指标: %s",
+			opts.Metric, strings.Join(TopMetrics, ", ")))
+	}
+	if !opts.End.After(opts.Start) {
+		return nil, hwErrors.NewValidationError("结束时间必须晚于起始时间")
+	}
+
+	interval := StatsIntervalHour
+	if opts.Kind == TopKindURLs {
+		interval = StatsIntervalDay
+	}
+	start, end := AlignStatsRange(opts.Start, opts.End, interval)
+
+	domainName := "all"
+	if len(opts.Domains) > 0 {
+		domainName = strings.Join(opts.Domains, ",")
+	}
+	enterpriseProjectID := c.enterpriseProjectID()
+	var serviceArea *string
+	if opts.ServiceArea != "" {
+		serviceArea = &opts.ServiceArea
+	}
+
+	logx.Debugf("查询 TOP %s，指标: %s，域名: %s，时间: %s - %s", opts.Kind, opts.Metric, domainName, start, end)
+
+	items := []TopItem{}
+	switch opts.Kind {
+	case TopKindURLs:
+		response, err := c.cdnClient.ShowTopUrl(&model.ShowTopUrlRequest{
+			StartTime:           start.UnixMilli(),
+			EndTime:             end.UnixMilli(),
+			DomainName:          domainName,
+			StatType:            opts.Metric,
+			ServiceArea:         serviceArea,
+			EnterpriseProjectId: &enterpriseProjectID,
+		})
+		if err != nil {
+			logx.Debugf("查询 TOP URL 失败: %v", err)
+			return nil, hwErrors.FromSDKError(err)
+		}
+		if response.TopUrlSummary != nil {
+			for _, summary := range *response.TopUrlSummary {
+				items = append(items, TopItem{Name: getStringValue(summary.Url), Value: getInt64Value(summary.Value)})
+			}
+		}
+	case TopKindReferers:
+		response, err := c.cdnClient.ListCdnDomainTopRefers(&model.ListCdnDomainTopRefersRequest{
+			StartTime:           start.UnixMilli(),
+			EndTime:             end.UnixMilli(),
+			DomainName:          domainName,
+			StatType:            opts.Metric,
+			ServiceArea:         serviceArea,
+			EnterpriseProjectId: &enterpriseProjectID,
+		})
+		if err != nil {
+			logx.Debugf("查询 TOP Referer 失败: %v", err)
+			return nil, hwErrors.FromSDKError(err)
+		}
+		if response.TopReferSummary != nil {
+			for _, summary := range *response.TopReferSummary {
+				items = append(items, TopItem{Name: getStringValue(summary.Refer), Value: getInt64Value(summary.Value)})
+			}
+		}
+	case TopKindIPs:
+		response, err := c.cdnClient.ListCdnDomainTopIps(&model.ListCdnDomainTopIpsRequest{
+			StartTime:           start.UnixMilli(),
+			EndTime:             end.UnixMilli(),
+			DomainName:          domainName,
+			StatType:            opts.Metric,
+			ServiceArea:         serviceArea,
+			EnterpriseProjectId: &enterpriseProjectID,
+		})
+		if err != nil {
+			logx.Debugf("查询 TOP IP 失败: %v", err)
+			return nil, hwErrors.FromSDKError(err)
+		}
+		if response.TopIpSummary != nil {
+			for _, summary := range *response.TopIpSummary {
+				items = append(items, TopItem{Name: getStringValue(summary.Ip), Value: getInt64Value(summary.Value)})
+			}
+		}
+	}
+
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Value > items[j].Value })
+	if opts.Limit > 0 && len(items) > opts.Limit {
+		items = items[:opts.Limit]
+	}
+	for i := range items {
+		items[i].Rank = i + 1
+	}
+
+	return &TopResult{
+		Kind:   opts.Kind,
+		Metric: opts.Metric,
+		Start:  start.Local().Format("2006-01-02 15:04:05"),
+		End:    end.Local().Format("2006-01-02 15:04:05"),
+		Items:  items,
+	}, nil
+}
@@ -0,0 +1,101 @@
+package cdn
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestGetTopURLs(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("stat_type") != "req_num" || query.Get("domain_name") != "all" {
+			t.Errorf("请求参数错误: %s", r.URL.RawQuery)
+		}
+		// TOP URL 只接受东八区零点
+		start := time.Date(2026, 10, 15, 0, 0, 0, 0, statsTimeZone).UnixMilli()
+		if query.Get("start_time") != fmt.Sprint(start) || query.Get("end_time") != fmt.Sprint(start+2*24*3600*1000) {
+			t.Errorf("时间范围应按天对齐: %s - %s", query.Get("start_time"), query.Get("end_time"))
+		}
+		writeJSON(t, w, map[string]interface{}{
+			"top_url_summary": []map[string]interface{}{
+				{"url": "http://cdn.example.com/a.js", "value": 10},
+				{"url": "http://cdn.example.com/b.js", "value": 30},
+				{"url": "http://cdn.example.com/c.js", "value": 20},
+			},
+		})
+	})
+
+	end := time.Date(2026, 10, 16, 12, 0, 0, 0, statsTimeZone)
+	result, err := client.GetTop(TopOptions{
+		Kind:   TopKindURLs,
+		Metric: MetricReqNum,
+		Start:  end.Add(-24 * time.Hour),
+		End:    end,
+		Limit:  2,
+	})
+	if err != nil {
+		t.Fatalf("查询失败: %v", err)
+	}
+	if len(result.Items) != 2 {
+		t.Fatalf("期望返回 2 条，实际为 %+v", result.Items)
+	}
+	first, second := result.Items[0], result.Items[1]
+	if first.Rank != 1 || !strings.HasSuffix(first.Name, "b.js") || second.Rank != 2 || second.Value != 20 {
+		t.Errorf("排行应按数值降序: %+v", result.Items)
+	}
+}
+
+func TestGetTopIPsAndReferers(t *testing.T) {
+	var paths []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		if r.URL.Query().Get("domain_name") != "a.example.com,b.example.com" {
+			t.Errorf("域名参数错误: %s", r.URL.RawQuery)
+		}
+		writeJSON(t, w, map[string]interface{}{
+			"top_ip_summary":    []map[string]interface{}{{"ip": "10.0.0.1", "value": 2048}},
+			"top_refer_summary": []map[string]interface{}{{"refer": "https://ref.example.com/", "value": 1024}},
+		})
+	})
+
+	now := time.Now()
+	for kind, name := range map[string]string{TopKindIPs: "10.0.0.1", TopKindReferers: "https://ref.example.com/"} {
+		result, err := client.GetTop(TopOptions{
+			Kind:    kind,
+			Domains: []string{"a.example.com", "b.example.com"},
+			Metric:  MetricFlux,
+			Start:   now.Add(-time.Hour),
+			End:     now,
+		})
+		if err != nil {
+			t.Fatalf("查询 %s 失败: %v", kind, err)
+		}
+		if len(result.Items) != 1 || result.Items[0].Name != name {
+			t.Errorf("%s 排行错误: %+v", kind, result.Items)
+		}
+	}
+	if len(paths) != 2 || paths[0] == paths[1] {
+		t.Errorf("应分别调用 IP 和 Referer 接口: %v", paths)
+	}
+}
+
+func TestGetTopValidation(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("参数无效时不应发送请求")
+	})
+	now := time.Now()
+
+	tests := []TopOptions{
+		{Kind: "uas", Metric: MetricFlux, Start: now.Add(-time.Hour), End: now},
+		{Kind: TopKindURLs, Metric: MetricBandwidth, Start: now.Add(-time.Hour), End: now},
+		{Kind: TopKindIPs, Metric: MetricFlux, Start: now, End: now.Add(-time.Hour)},
+	}
+	for _, opts := range tests {
+		if _, err := client.GetTop(opts); err == nil {
+			t.Errorf("期望参数 %+v 校验失败", opts)
+		}
+	}
+}
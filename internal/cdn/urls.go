@@ -0,0 +1,209 @@
+package cdn
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/url"
+	"path/filepath"
+	"strings"
+)
+
+// URL 输入格式
+const (
+	InputFormatAuto = "auto"
+	InputFormatText = "text"
+	InputFormatJSON = "json"
+	InputFormatCSV  = "csv"
+)
+
+// InputFormats 支持的 URL 输入格式
+var InputFormats = []string{InputFormatAuto, InputFormatText, InputFormatJSON, InputFormatCSV}
+
+// DetectInputFormat 根据文件扩展名和内容推断输入格式
+func DetectInputFormat(path string, data []byte) string {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return InputFormatJSON
+	case ".csv":
+		return InputFormatCSV
+	}
+
+	trimmed := bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if len(trimmed) > 0 && (trimmed[0] == '[' || trimmed[0] == '{') {
+		return InputFormatJSON
+	}
+	return InputFormatText
+}
+
+// ParseURLs 按指定格式解析 URL 列表，format 为 auto 或空时根据 path 和内容推断
+//
+//   - text: 每行一个 URL，忽略空行和以 # 开头的注释行
+//   - json: 字符串数组、{"urls": [...]} 或包含 url 字段的对象数组
+//   - csv:  存在名为 url 的表头时使用该列，否则使用第一列；忽略以 # 开头的行
+func ParseURLs(data []byte, format, path string) ([]string, error) {
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if format == "" || format == InputFormatAuto {
+		format = DetectInputFormat(path, data)
+	}
+
+	switch format {
+	case InputFormatText:
+		return parseTextURLs(data)
+	case InputFormatJSON:
+		return parseJSONURLs(data)
+	case InputFormatCSV:
+		return parseCSVURLs(data)
+	default:
+		return nil, fmt.Errorf("不支持的输入格式: %s，支持的格式: %s", format, strings.Join(InputFormats, ", "))
+	}
+}
+
+// ReadURLs 从 reader 读取并解析 URL 列表
+func ReadURLs(r io.Reader, format, path string) ([]string, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("读取 URL 列表失败: %v", err)
+	}
+	return ParseURLs(data, format, path)
+}
+
+// parseTextURLs 解析按行分隔的 URL 列表
+func parseTextURLs(data []byte) ([]string, error) {
+	var urls []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		urls = append(urls, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("解析文本 URL 列表失败: %v", err)
+	}
+	return urls, nil
+}
+
+// parseJSONURLs 解析 JSON 格式的 URL 列表
+func parseJSONURLs(data []byte) ([]string, error) {
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("解析 JSON URL 列表失败: %v", err)
+	}
+
+	// {"urls": [...]}
+	if obj, ok := raw.(map[string]interface{}); ok {
+		list, exists := obj["urls"]
+		if !exists {
+			return nil, fmt.Errorf("JSON 对象中缺少 urls 字段")
+		}
+		raw = list
+	}
+
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("JSON URL 列表必须是数组或包含 urls 字段的对象")
+	}
+
+	urls := make([]string, 0, len(items))
+	for i, item := range items {
+		switch v := item.(type) {
+		case string:
+			urls = append(urls, v)
+		case map[string]interface{}:
+			u, ok := v["url"].(string)
+			if !ok {
+				return nil, fmt.Errorf("JSON 数组第 %d 个元素缺少 url 字段", i+1)
+			}
+			urls = append(urls, u)
+		default:
+			return nil, fmt.Errorf("JSON 数组第 %d 个元素必须是字符串或包含 url 字段的对象", i+1)
+		}
+	}
+	return urls, nil
+}
+
+// parseCSVURLs 解析 CSV 格式的 URL 列表
+func parseCSVURLs(data []byte) ([]string, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("解析 CSV URL 列表失败: %v", err)
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+
+	// 表头中存在 url 列时使用该列，否则使用第一列
+	column := 0
+	start := 0
+	for i, name := range records[0] {
+		if strings.EqualFold(strings.TrimSpace(name), "url") {
+			column = i
+			start = 1
+			break
+		}
+	}
+
+	var urls []string
+	for _, record := range records[start:] {
+		if column < len(record) {
+			urls = append(urls, record[column])
+		}
+	}
+	return urls, nil
+}
+
+// NormalizeURLs 规范化并去重 URL 列表，保持首次出现的顺序
+// 规范化包括去除首尾空白、协议和主机名转小写、去除片段（#fragment）
+func NormalizeURLs(urls []string) []string {
+	seen := make(map[string]struct{}, len(urls))
+	result := make([]string, 0, len(urls))
+	for _, raw := range urls {
+		normalized := NormalizeURL(raw)
+		if normalized == "" {
+			continue
+		}
+		if _, exists := seen[normalized]; exists {
+			continue
+		}
+		seen[normalized] = struct{}{}
+		result = append(result, normalized)
+	}
+	return result
+}
+
+// NormalizeURL 规范化单个 URL，无法解析的 URL 仅去除首尾空白后原样返回
+// 路径和查询参数保持原样，不改变其编码方式
+func NormalizeURL(raw string) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme == "" || u.Host == "" {
+		return raw
+	}
+
+	// 去除片段，片段不会发送到服务端
+	if idx := strings.Index(raw, "#"); idx >= 0 {
+		raw = raw[:idx]
+	}
+
+	// 协议和主机名不区分大小写，统一转为小写
+	prefix := u.Scheme + "://" + u.Host
+	if len(raw) >= len(prefix) && strings.EqualFold(raw[:len(prefix)], prefix) {
+		raw = strings.ToLower(prefix) + raw[len(prefix):]
+	}
+	return raw
+}
@@ -0,0 +1,149 @@
+package cdn
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseURLs(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format string
+		path   string
+		want   []string
+	}{
+		{
+			name:   "文本格式忽略注释和空行",
+			data:   "# 静态资源\nhttps://example.com/a.js\n\n  https://example.com/b.css  \n#https://example.com/c.png\n",
+			format: InputFormatText,
+			want:   []string{"https://example.com/a.js", "https://example.com/b.css"},
+		},
+		{
+			name:   "JSON 字符串数组",
+			data:   `["https://example.com/a.js", "https://example.com/b.css"]`,
+			format: InputFormatJSON,
+			want:   []string{"https://example.com/a.js", "https://example.com/b.css"},
+		},
+		{
+			name:   "JSON urls 对象",
+			data:   `{"urls": ["https://example.com/a.js"]}`,
+			format: InputFormatJSON,
+			want:   []string{"https://example.com/a.js"},
+		},
+		{
+			name:   "JSON 对象数组",
+			data:   `[{"url": "https://example.com/a.js", "status": "失败"}]`,
+			format: InputFormatJSON,
+			want:   []string{"https://example.com/a.js"},
+		},
+		{
+			name:   "CSV 带表头",
+			data:   "status,url\nfailed,https://example.com/a.js\n# 注释\nfailed,https://example.com/b.css\n",
+			format: InputFormatCSV,
+			want:   []string{"https://example.com/a.js", "https://example.com/b.css"},
+		},
+		{
+			name:   "CSV 无表头使用第一列",
+			data:   "https://example.com/a.js,file\nhttps://example.com/b.css,file\n",
+			format: InputFormatCSV,
+			want:   []string{"https://example.com/a.js", "https://example.com/b.css"},
+		},
+		{
+			name: "自动识别 JSON 内容",
+			data: "\n  [\"https://example.com/a.js\"]",
+			want: []string{"https://example.com/a.js"},
+		},
+		{
+			name: "根据扩展名识别 CSV",
+			data: "url\nhttps://example.com/a.js\n",
+			path: "urls.csv",
+			want: []string{"https://example.com/a.js"},
+		},
+		{
+			name: "自动识别文本并去除 BOM",
+			data: "\xef\xbb\xbfhttps://example.com/a.js\n",
+			path: "-",
+			want: []string{"https://example.com/a.js"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseURLs([]byte(tt.data), tt.format, tt.path)
+			if err != nil {
+				t.Fatalf("解析失败: %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("期望 %v，实际为 %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestParseURLsErrors(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   string
+		format string
+	}{
+		{"不支持的格式", "https://example.com/a.js", "xml"},
+		{"无效 JSON", `["https://example.com/a.js"`, InputFormatJSON},
+		{"JSON 对象缺少 urls", `{"items": []}`, InputFormatJSON},
+		{"JSON 元素类型错误", `[1, 2]`, InputFormatJSON},
+		{"JSON 对象元素缺少 url", `[{"path": "/a.js"}]`, InputFormatJSON},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseURLs([]byte(tt.data), tt.format, ""); err == nil {
+				t.Error("期望返回错误")
+			}
+		})
+	}
+}
+
+func TestReadURLs(t *testing.T) {
+	got, err := ReadURLs(strings.NewReader("https://example.com/a.js\nhttps://example.com/b.css\n"), InputFormatAuto, "-")
+	if err != nil {
+		t.Fatalf("读取失败: %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("期望读取到 2 个 URL，实际为 %d", len(got))
+	}
+}
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{"  https://example.com/a.js  ", "https://example.com/a.js"},
+		{"HTTPS://Example.COM/Path/A.js", "https://example.com/Path/A.js"},
+		{"https://example.com/a.js#section", "https://example.com/a.js"},
+		{"https://example.com/a%20b.js?v=1", "https://example.com/a%20b.js?v=1"},
+		{"example.com/a.js", "example.com/a.js"},
+		{"   ", ""},
+	}
+
+	for _, tt := range tests {
+		if got := NormalizeURL(tt.in); got != tt.want {
+			t.Errorf("NormalizeURL(%q) = %q，期望 %q", tt.in, got, tt.want)
+		}
+	}
+}
+
+func TestNormalizeURLs(t *testing.T) {
+	got := NormalizeURLs([]string{
+		"https://example.com/b.css",
+		"https://EXAMPLE.com/a.js",
+		"",
+		"https://example.com/a.js#top",
+		"https://example.com/b.css",
+	})
+	want := []string{"https://example.com/b.css", "https://example.com/a.js"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("期望 %v，实际为 %v", want, got)
+	}
+}
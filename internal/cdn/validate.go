@@ -0,0 +1,178 @@
+package cdn
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"unicode"
+
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+)
+
+// MaxURLLength 单个 URL 允许的最大长度
+const MaxURLLength = 4096
+
+// maxReportedInvalidURLs 校验失败时错误信息中最多列出的 URL 数量
+const maxReportedInvalidURLs = 10
+
+// ValidateURL 在本地校验单个刷新/预热 URL
+// refreshType 为 directory/dir 时要求以 / 结尾
+func ValidateURL(raw, refreshType string) error {
+	if raw == "" {
+		return fmt.Errorf("URL 不能为空")
+	}
+	if len(raw) > MaxURLLength {
+		return fmt.Errorf("URL 长度 %d 超过上限 %d", len(raw), MaxURLLength)
+	}
+	for _, r := range raw {
+		if unicode.IsSpace(r) || unicode.IsControl(r) {
+			return fmt.Errorf("URL 包含空白或控制字符，请先进行 URL 编码")
+		}
+		if r > unicode.MaxASCII {
+			return fmt.Errorf("URL 包含未编码的非 ASCII 字符，请先进行 URL 编码")
+		}
+	}
+	// url.Parse 不校验查询参数中的编码，整体检查一次百分号编码
+	if _, err := url.PathUnescape(raw); err != nil {
+		return fmt.Errorf("URL 包含无效的 %% 编码: %v", err)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("URL 格式无效: %v", err)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return fmt.Errorf("URL 必须以 http:// 或 https:// 开头")
+	}
+	if u.Hostname() == "" {
+		return fmt.Errorf("URL 缺少域名")
+	}
+	if u.User != nil {
+		return fmt.Errorf("URL 不能包含用户信息")
+	}
+	if err := validateHostname(u.Hostname()); err != nil {
+		return err
+	}
+
+	switch refreshType {
+	case "directory", "dir":
+		if !strings.HasSuffix(u.Path, "/") || u.RawQuery != "" {
+			return fmt.Errorf("目录刷新的 URL 必须以 / 结尾且不能包含查询参数")
+		}
+	}
+	return nil
+}
+
+// ValidateURLs 校验 URL 列表，汇总所有无效 URL 后返回参数验证错误
+func ValidateURLs(urls []string, refreshType string) error {
+	var problems []string
+	invalid := 0
+	for _, raw := range urls {
+		if err := ValidateURL(raw, refreshType); err != nil {
+			invalid++
+			if len(problems) < maxReportedInvalidURLs {
+				problems = append(problems, fmt.Sprintf("  %s: %v", raw, err))
+			}
+		}
+	}
+	if invalid == 0 {
+		return nil
+	}
+
+	message := fmt.Sprintf("%d 个 URL 校验失败:\n%s", invalid, strings.Join(problems, "\n"))
+	if invalid > len(problems) {
+		message += fmt.Sprintf("\n  ... 以及其他 %d 个", invalid-len(problems))
+	}
+	return hwErrors.NewValidationError(message)
+}
+
+// validateHostname 校验域名格式，仅允许字母、数字、连字符和点
+func validateHostname(host string) error {
+	if len(host) > 253 {
+		return fmt.Errorf("域名长度超过 253 个字符")
+	}
+	for _, label := range strings.Split(strings.TrimSuffix(host, "."), ".") {
+		if label == "" || len(label) > 63 {
+			return fmt.Errorf("域名 %s 格式无效", host)
+		}
+		if strings.HasPrefix(label, "-") || strings.HasSuffix(label, "-") {
+			return fmt.Errorf("域名 %s 格式无效", host)
+		}
+		for _, r := range label {
+			if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+				return fmt.Errorf("域名 %s 包含无效字符，中文域名请使用 punycode 编码", host)
+			}
+		}
+	}
+	return nil
+}
+
+// URLHosts 返回 URL 列表中去重后的域名，保持首次出现的顺序
+func URLHosts(urls []string) []string {
+	seen := make(map[string]struct{})
+	var hosts []string
+	for _, raw := range urls {
+		u, err := url.Parse(raw)
+		if err != nil || u.Hostname() == "" {
+			continue
+		}
+		host := strings.ToLower(u.Hostname())
+		if _, exists := seen[host]; exists {
+			continue
+		}
+		seen[host] = struct{}{}
+		hosts = append(hosts, host)
+	}
+	return hosts
+}
+
+// matchDomain 判断域名是否属于加速域名，支持泛域名
+// 泛域名只匹配一级子域名，如 *.example.com 匹配 a.example.com，不匹配 a.b.example.com
+func matchDomain(host, domain string) bool {
+	domain = strings.ToLower(domain)
+	if host == domain {
+		return true
+	}
+	if strings.HasPrefix(domain, "*.") {
+		label, found := strings.CutSuffix(host, domain[1:])
+		return found && label != "" && !strings.Contains(label, ".")
+	}
+	return false
+}
+
+// UnknownHosts 返回不属于任何加速域名的域名
+func UnknownHosts(hosts, domains []string) []string {
+	var unknown []string
+	for _, host := range hosts {
+		found := false
+		for _, domain := range domains {
+			if matchDomain(host, domain) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			unknown = append(unknown, host)
+		}
+	}
+	return unknown
+}
+
+// CheckURLDomains 校验 URL 的域名是否都是当前账号下的加速域名
+func (c *Client) CheckURLDomains(urls []string) error {
+	hosts := URLHosts(urls)
+	if len(hosts) == 0 {
+		return nil
+	}
+
+	domains, err := c.ListDomainNames()
+	if err != nil {
+		return err
+	}
+
+	if unknown := UnknownHosts(hosts, domains); len(unknown) > 0 {
+		return hwErrors.NewValidationError(fmt.Sprintf(
+			"以下域名不是当前账号的 CDN 加速域名，请检查拼写: %s", strings.Join(unknown, ", ")))
+	}
+	return nil
+}
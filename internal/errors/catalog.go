@@ -0,0 +1,213 @@
+package errors
+
+import (
+	"os"
+	"strings"
+)
+
+// CatalogEntry 已知错误码的分类、可重试性和处理建议
+type CatalogEntry struct {
+	Type      ErrorType
+	Retryable bool
+	HintZH    string // 中文处理建议
+	HintEN    string // 英文处理建议
+}
+
+// errorCatalog 华为云 CDN 和 IAM（经 API 网关）返回的已知错误码
+var errorCatalog = map[string]CatalogEntry{
+	"APIGW.0101": {
+		Type:   ErrorTypeNotFound,
+		HintZH: "接口不存在或未发布，请检查区域和 endpoint 配置",
+		HintEN: "The API does not exist; check the region and endpoint settings",
+	},
+	"APIGW.0301": {
+		Type:   ErrorTypeAuth,
+		HintZH: "IAM 认证信息错误，请检查 AK/SK 是否正确、是否已停用，以及本机时间是否准确",
+		HintEN: "IAM authentication failed; check that the AK/SK is correct and enabled, and that the local clock is accurate",
+	},
+	"APIGW.0302": {
+		Type:   ErrorTypePermission,
+		HintZH: "IAM 用户不允许访问该接口，请检查访问控制策略",
+		HintEN: "The IAM user is not allowed to access this API; check the access control policy",
+	},
+	"APIGW.0303": {
+		Type:   ErrorTypeAuth,
+		HintZH: "APP 认证信息错误，请检查认证方式和凭证",
+		HintEN: "App authentication failed; check the authentication method and credentials",
+	},
+	"APIGW.0308": {
+		Type:      ErrorTypeThrottle,
+		Retryable: true,
+		HintZH:    "请求超过 API 流控上限，请降低并发（如 --concurrency）后重试",
+		HintEN:    "The API rate limit was exceeded; lower the concurrency (e.g. --concurrency) and retry",
+	},
+	"CDN.0000": {
+		Type:      ErrorTypeServer,
+		Retryable: true,
+		HintZH:    "CDN 服务内部错误，请稍后重试，持续出现时请携带 RequestID 联系华为云技术支持",
+		HintEN:    "CDN internal error; retry later and contact Huawei Cloud support with the RequestID if it persists",
+	},
+	"CDN.0001": {
+		Type:   ErrorTypeValidation,
+		HintZH: "请求参数错误，请根据错误信息检查参数",
+		HintEN: "Invalid request parameter; check the parameters according to the error message",
+	},
+	// 加速域名
+	"CDN.0100": {
+		Type:   ErrorTypeNotFound,
+		HintZH: "加速域名不存在，请检查域名拼写以及是否属于当前账号和配置的企业项目（enterprise_project_id）",
+		HintEN: "The domain does not exist; check the spelling and that it belongs to the current account and the configured enterprise project (enterprise_project_id)",
+	},
+	"CDN.0101": {
+		Type:   ErrorTypeValidation,
+		HintZH: "加速域名已存在，可能已被当前账号或其他账号添加",
+		HintEN: "The domain already exists in this or another account",
+	},
+	"CDN.0102": {
+		Type:   ErrorTypeThrottle,
+		HintZH: "加速域名数量超过配额，请删除不再使用的域名或申请提高配额",
+		HintEN: "The domain quota is exceeded; delete unused domains or request a higher quota",
+	},
+	"CDN.0103": {
+		Type:   ErrorTypeValidation,
+		HintZH: "加速域名当前状态不允许该操作（如已停用、配置中或审核中），请稍后再试",
+		HintEN: "The domain status does not allow this operation (e.g. disabled, configuring or under review); try again later",
+	},
+	// 刷新和预热
+	"CDN.0200": {
+		Type:   ErrorTypeThrottle,
+		HintZH: "刷新 URL 或目录数量超过当日配额，请减少提交数量或次日再提交",
+		HintEN: "The daily refresh quota is exceeded; submit fewer URLs or retry tomorrow",
+	},
+	"CDN.0201": {
+		Type:   ErrorTypeThrottle,
+		HintZH: "预热 URL 数量超过当日配额，请减少提交数量或次日再提交",
+		HintEN: "The daily preload quota is exceeded; submit fewer URLs or retry tomorrow",
+	},
+	"CDN.0202": {
+		Type:   ErrorTypeValidation,
+		HintZH: "URL 格式错误或不属于当前账号的加速域名，请检查协议和域名",
+		HintEN: "The URL is malformed or does not belong to a domain in this account; check the scheme and domain",
+	},
+	"CDN.0203": {
+		Type:      ErrorTypeThrottle,
+		Retryable: true,
+		HintZH:    "刷新预热任务提交过于频繁，请降低并发（如 --concurrency）后重试",
+		HintEN:    "Refresh or preload tasks are submitted too frequently; lower the concurrency (e.g. --concurrency) and retry",
+	},
+	// 参数校验
+	"CDN.1000": {
+		Type:   ErrorTypeValidation,
+		HintZH: "参数格式错误，请根据错误信息检查参数",
+		HintEN: "Malformed parameter; check the parameters according to the error message",
+	},
+	"CDN.1001": {
+		Type:   ErrorTypeValidation,
+		HintZH: "缺少必填参数，请根据错误信息补充参数",
+		HintEN: "A required parameter is missing; add it according to the error message",
+	},
+	"CDN.1002": {
+		Type:   ErrorTypeValidation,
+		HintZH: "参数取值超出允许范围，请根据错误信息调整参数",
+		HintEN: "A parameter value is out of range; adjust it according to the error message",
+	},
+	// IAM
+	"IAM.0001": {
+		Type:   ErrorTypeValidation,
+		HintZH: "IAM 请求体格式错误",
+		HintEN: "The IAM request body is malformed",
+	},
+	"IAM.0002": {
+		Type:   ErrorTypeAuth,
+		HintZH: "IAM 认证失败，请检查 AK/SK 是否正确、是否已停用，以及本机时间是否准确",
+		HintEN: "IAM authentication failed; check that the AK/SK is correct and enabled, and that the local clock is accurate",
+	},
+	"IAM.0003": {
+		Type:   ErrorTypePermission,
+		HintZH: "IAM 用户没有执行该操作的权限，请检查用户组和授权策略",
+		HintEN: "The IAM user is not allowed to perform this action; check the user groups and policies",
+	},
+	"IAM.0004": {
+		Type:   ErrorTypeNotFound,
+		HintZH: "IAM 资源不存在，请检查 Domain ID 和项目是否正确",
+		HintEN: "The IAM resource does not exist; check the Domain ID and project",
+	},
+	"IAM.0006": {
+		Type:      ErrorTypeServer,
+		Retryable: true,
+		HintZH:    "IAM 服务内部错误，请稍后重试",
+		HintEN:    "IAM internal error; retry later",
+	},
+	"IAM.0007": {
+		Type:   ErrorTypeValidation,
+		HintZH: "IAM 请求参数无效，请根据错误信息检查参数",
+		HintEN: "Invalid IAM request parameter; check the parameters according to the error message",
+	},
+	"IAM.0101": {
+		Type:   ErrorTypeAuth,
+		HintZH: "Access Key 不存在或已停用，请在控制台确认 AK 状态或运行 hwcctl configure 重新配置",
+		HintEN: "The access key does not exist or is disabled; check it in the console or run hwcctl configure",
+	},
+}
+
+// typeHints 没有匹配的错误码时按错误类型给出的通用处理建议，依次为中文和英文
+var typeHints = map[ErrorType][2]string{
+	ErrorTypeAuth: {
+		"请检查 AK/SK 和 Domain ID 配置，可运行 hwcctl configure 重新配置",
+		"Check the AK/SK and Domain ID settings, or run hwcctl configure",
+	},
+	ErrorTypePermission: {
+		"请确认 IAM 用户拥有 CDN 相关权限（如 CDN FullAccess）以及企业项目权限",
+		"Make sure the IAM user has CDN permissions (e.g. CDN FullAccess) and access to the enterprise project",
+	},
+	ErrorTypeNotFound: {
+		"请检查域名、任务 ID 等资源是否存在以及是否属于当前账号和企业项目",
+		"Check that the domain, task ID or other resource exists and belongs to the current account and enterprise project",
+	},
+	ErrorTypeThrottle: {
+		"请求过于频繁或配额不足，请降低并发或稍后重试",
+		"Too many requests or quota exhausted; lower the concurrency or retry later",
+	},
+	ErrorTypeServer: {
+		"服务端错误，请稍后重试，持续出现时请携带 RequestID 联系华为云技术支持",
+		"Server error; retry later and contact Huawei Cloud support with the RequestID if it persists",
+	},
+	ErrorTypeNetwork: {
+		"请检查网络连接、代理设置和 endpoint 配置",
+		"Check the network connection, proxy settings and endpoint configuration",
+	},
+}
+
+// LookupErrorCode 查询已知错误码的分类信息
+func LookupErrorCode(code string) (CatalogEntry, bool) {
+	entry, ok := errorCatalog[strings.ToUpper(strings.TrimSpace(code))]
+	return entry, ok
+}
+
+// hintFor 返回错误码或错误类型对应的处理建议，语言由环境变量 LC_ALL、LC_MESSAGES、LANG 决定
+func hintFor(code string, errorType ErrorType) string {
+	english := isEnglishLocale()
+	if entry, ok := LookupErrorCode(code); ok {
+		if english {
+			return entry.HintEN
+		}
+		return entry.HintZH
+	}
+	if hints, ok := typeHints[errorType]; ok {
+		if english {
+			return hints[1]
+		}
+		return hints[0]
+	}
+	return ""
+}
+
+// isEnglishLocale 判断当前语言环境是否为英文
+func isEnglishLocale() bool {
+	for _, key := range []string{"LC_ALL", "LC_MESSAGES", "LANG"} {
+		if value := os.Getenv(key); value != "" {
+			return strings.HasPrefix(strings.ToLower(value), "en")
+		}
+	}
+	return false
+}
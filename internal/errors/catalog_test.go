@@ -0,0 +1,66 @@
+package errors
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/sdkerr"
+)
+
+func TestLookupErrorCode(t *testing.T) {
+	entry, ok := LookupErrorCode(" apigw.0308 ")
+	if !ok || entry.Type != ErrorTypeThrottle || !entry.Retryable {
+		t.Errorf("APIGW.0308 应为可重试的限流错误: %+v", entry)
+	}
+	if _, ok := LookupErrorCode("CDN.9999"); ok {
+		t.Error("未知错误码不应命中目录")
+	}
+}
+
+func TestHintForLocale(t *testing.T) {
+	t.Setenv("LC_ALL", "")
+	t.Setenv("LC_MESSAGES", "")
+
+	t.Setenv("LANG", "zh_CN.UTF-8")
+	if hint := hintFor("APIGW.0301", ErrorTypeAuth); hint != errorCatalog["APIGW.0301"].HintZH {
+		t.Errorf("中文环境应返回中文提示: %s", hint)
+	}
+
+	t.Setenv("LANG", "en_US.UTF-8")
+	if hint := hintFor("APIGW.0301", ErrorTypeAuth); hint != errorCatalog["APIGW.0301"].HintEN {
+		t.Errorf("英文环境应返回英文提示: %s", hint)
+	}
+	if hint := hintFor("CDN.9999", ErrorTypeNetwork); hint != typeHints[ErrorTypeNetwork][1] {
+		t.Errorf("未知错误码应按错误类型给出提示: %s", hint)
+	}
+	if hint := hintFor("CDN.9999", ErrorTypeValidation); hint != "" {
+		t.Errorf("没有对应提示时应为空: %s", hint)
+	}
+}
+
+func TestCatalogEntriesComplete(t *testing.T) {
+	for code, entry := range errorCatalog {
+		if entry.Type == "" || entry.HintZH == "" || entry.HintEN == "" {
+			t.Errorf("错误码 %s 缺少类型或中英文提示: %+v", code, entry)
+		}
+	}
+}
+
+func TestFromSDKErrorUsesCatalog(t *testing.T) {
+	t.Setenv("LC_ALL", "zh_CN.UTF-8")
+	err := FromSDKError(&sdkerr.ServiceResponseError{StatusCode: http.StatusBadRequest, ErrorCode: "CDN.0100",
+		ErrorMessage: "domain not found", RequestId: "req-100"})
+
+	entry := errorCatalog["CDN.0100"]
+	if err.Type != entry.Type || err.Retryable != entry.Retryable || err.Hint != entry.HintZH {
+		t.Errorf("CDN.0100 应按目录分类，实际为 %+v", err)
+	}
+	if err.Code != "CDN.0100" || err.StatusCode != http.StatusBadRequest || err.RequestID != "req-100" {
+		t.Errorf("应保留错误码、状态码和请求 ID: %+v", err)
+	}
+
+	iamErr := FromSDKError(&sdkerr.ServiceResponseError{StatusCode: http.StatusForbidden, ErrorCode: "IAM.0003"})
+	if iamErr.Type != ErrorTypePermission {
+		t.Errorf("IAM.0003 应为权限错误，实际为 %+v", iamErr)
+	}
+}
@@ -1,9 +1,15 @@
 package errors
 
 import (
+	"context"
+	"encoding/json"
+	stderrors "errors"
 	"fmt"
+	"net"
 	"net/http"
 	"strings"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/sdkerr"
 )
 
 // ErrorType 错误类型
@@ -24,19 +30,60 @@ const (
 	ErrorTypeNotFound ErrorType = "NotFoundError"
 	// 限流错误
 	ErrorTypeThrottle ErrorType = "ThrottleError"
+	// 异步任务执行失败
+	ErrorTypeTaskFailed ErrorType = "TaskFailedError"
+	// 等待超时
+	ErrorTypeTimeout ErrorType = "TimeoutError"
+	// 线上状态与期望配置不一致
+	ErrorTypeDrift ErrorType = "DriftDetectedError"
+	// 证书即将过期
+	ErrorTypeCertExpiring ErrorType = "CertificateExpiringError"
 	// 未知错误
 	ErrorTypeUnknown ErrorType = "UnknownError"
 )
 
+// 进程退出码，由 HuaweiCloudError.Type 决定，便于脚本按失败类别处理
+const (
+	ExitCodeOK           = 0  // 成功
+	ExitCodeGeneral      = 1  // 未分类的错误
+	ExitCodeValidation   = 2  // 参数或配置错误
+	ExitCodeAuth         = 3  // 认证失败
+	ExitCodePermission   = 4  // 权限不足
+	ExitCodeNotFound     = 5  // 资源不存在
+	ExitCodeThrottle     = 6  // 限流或配额不足
+	ExitCodeServer       = 7  // 服务端错误
+	ExitCodeNetwork      = 8  // 网络错误
+	ExitCodeTaskFailed   = 9  // 异步任务执行失败
+	ExitCodeTimeout      = 10 // 等待超时
+	ExitCodeDrift        = 11 // 线上配置与期望不一致
+	ExitCodeCertExpiring = 12 // 证书即将过期
+)
+
+// exitCodes 错误类型与退出码的对应关系
+var exitCodes = map[ErrorType]int{
+	ErrorTypeValidation:   ExitCodeValidation,
+	ErrorTypeAuth:         ExitCodeAuth,
+	ErrorTypePermission:   ExitCodePermission,
+	ErrorTypeNotFound:     ExitCodeNotFound,
+	ErrorTypeThrottle:     ExitCodeThrottle,
+	ErrorTypeServer:       ExitCodeServer,
+	ErrorTypeNetwork:      ExitCodeNetwork,
+	ErrorTypeTaskFailed:   ExitCodeTaskFailed,
+	ErrorTypeTimeout:      ExitCodeTimeout,
+	ErrorTypeDrift:        ExitCodeDrift,
+	ErrorTypeCertExpiring: ExitCodeCertExpiring,
+}
+
 // HuaweiCloudError 华为云错误结构
 type HuaweiCloudError struct {
-	Type       ErrorType `json:"type"`
-	Code       string    `json:"code"`
-	Message    string    `json:"message"`
-	Details    string    `json:"details,omitempty"`
-	RequestID  string    `json:"request_id,omitempty"`
-	StatusCode int       `json:"status_code,omitempty"`
-	Retryable  bool      `json:"retryable"`
+	Type       ErrorType `json:"type" yaml:"type"`
+	Code       string    `json:"code" yaml:"code"`
+	Message    string    `json:"message" yaml:"message"`
+	Details    string    `json:"details,omitempty" yaml:"details,omitempty"`
+	RequestID  string    `json:"request_id,omitempty" yaml:"request_id,omitempty"`
+	StatusCode int       `json:"status_code,omitempty" yaml:"status_code,omitempty"`
+	Retryable  bool      `json:"retryable" yaml:"retryable"`
+	Hint       string    `json:"hint,omitempty" yaml:"hint,omitempty"`
 }
 
 // Error 实现 error 接口
@@ -86,13 +133,123 @@ func NewHTTPError(statusCode int, body string) *HuaweiCloudError {
 		Details:    body,
 		StatusCode: statusCode,
 		Retryable:  isRetryableStatusCode(statusCode),
+		Hint:       hintFor(code, errorType),
+	}
+}
+
+// newAPIError 根据接口返回的错误码创建错误，已知错误码按错误码目录分类，否则按状态码分类
+func newAPIError(statusCode int, code, message, requestID, details string) *HuaweiCloudError {
+	errorType := getErrorTypeFromStatusCode(statusCode)
+	retryable := isRetryableStatusCode(statusCode)
+	if entry, ok := LookupErrorCode(code); ok {
+		errorType = entry.Type
+		retryable = entry.Retryable
+	}
+	if message == "" {
+		message = getMessageFromStatusCode(statusCode)
+	}
+
+	return &HuaweiCloudError{
+		Type:       errorType,
+		Code:       code,
+		Message:    message,
+		Details:    details,
+		RequestID:  requestID,
+		StatusCode: statusCode,
+		Retryable:  retryable,
+		Hint:       hintFor(code, errorType),
+	}
+}
+
+// parseErrorBody 从华为云错误响应体中提取错误码和错误信息
+// 支持 {"error_code": "...", "error_msg": "..."}、{"code": "...", "message": "..."} 及其嵌套形式，
+// 例如 {"error": {"error_code": "CDN.0001", "error_msg": "..."}}
+func parseErrorBody(body string) (string, string) {
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(body), &data); err != nil {
+		return "", ""
+	}
+	return findErrorCode(data)
+}
+
+// findErrorCode 在 JSON 对象及其嵌套对象中查找错误码和错误信息
+func findErrorCode(data map[string]interface{}) (string, string) {
+	for _, keys := range [][2]string{{"error_code", "error_msg"}, {"code", "message"}} {
+		code, _ := data[keys[0]].(string)
+		message, _ := data[keys[1]].(string)
+		if code != "" {
+			return code, message
+		}
+	}
+	for _, value := range data {
+		if nested, ok := value.(map[string]interface{}); ok {
+			if code, message := findErrorCode(nested); code != "" {
+				return code, message
+			}
+		}
+	}
+	return "", ""
+}
+
+// FromSDKError 将华为云 SDK 返回的错误转换为 HuaweiCloudError
+// ServiceResponseError 保留状态码、错误码、错误信息和请求 ID，连接和超时错误转换为网络错误
+func FromSDKError(err error) *HuaweiCloudError {
+	if err == nil {
+		return nil
+	}
+
+	var hwErr *HuaweiCloudError
+	if stderrors.As(err, &hwErr) {
+		return hwErr
+	}
+
+	var respErr *sdkerr.ServiceResponseError
+	if stderrors.As(err, &respErr) {
+		if respErr.ErrorCode == "" {
+			// SDK 未能识别的响应体，按原始内容解析
+			parsed := ParseHuaweiCloudError(respErr.StatusCode, respErr.ErrorMessage)
+			parsed.RequestID = respErr.RequestId
+			return parsed
+		}
+		return newAPIError(respErr.StatusCode, respErr.ErrorCode, respErr.ErrorMessage,
+			respErr.RequestId, respErr.EncodedAuthorizationMessage)
+	}
+
+	var connErr *sdkerr.ConnectionError
+	if stderrors.As(err, &connErr) {
+		return newNetworkErrorWithHint(connErr.ErrorMessage)
 	}
+	var timeoutErr *sdkerr.RequestTimeoutError
+	if stderrors.As(err, &timeoutErr) {
+		return newNetworkErrorWithHint(timeoutErr.ErrorMessage)
+	}
+	var credErr *sdkerr.CredentialsTypeError
+	if stderrors.As(err, &credErr) {
+		authErr := NewAuthError(credErr.ErrorMessage)
+		authErr.Hint = hintFor(authErr.Code, ErrorTypeAuth)
+		return authErr
+	}
+	var netErr net.Error
+	if stderrors.As(err, &netErr) {
+		return newNetworkErrorWithHint(err.Error())
+	}
+
+	return ParseHuaweiCloudError(http.StatusInternalServerError, err.Error())
+}
+
+// newNetworkErrorWithHint 创建带处理建议的网络错误
+func newNetworkErrorWithHint(message string) *HuaweiCloudError {
+	netErr := NewNetworkError(message)
+	netErr.Hint = hintFor(netErr.Code, ErrorTypeNetwork)
+	return netErr
 }
 
 // ParseHuaweiCloudError 解析华为云 API 错误响应
+// 响应体包含错误码时按错误码目录分类，否则根据响应内容和状态码推断错误类型
 func ParseHuaweiCloudError(statusCode int, body string) *HuaweiCloudError {
-	// 这里可以解析华为云特定的错误格式
-	// 例如：{"error": {"code": "CDN.0001", "message": "Invalid parameter"}}
+	if code, message := parseErrorBody(body); code != "" {
+		return newAPIError(statusCode, code, message, "", body)
+	}
 
 	// 尝试从响应体中提取错误信息
 	if strings.Contains(body, "Invalid") || strings.Contains(body, "invalid") {
@@ -114,6 +271,7 @@ func ParseHuaweiCloudError(statusCode int, body string) *HuaweiCloudError {
 			Details:    body,
 			StatusCode: statusCode,
 			Retryable:  false,
+			Hint:       hintFor("Unauthorized", ErrorTypeAuth),
 		}
 	}
 
@@ -125,6 +283,7 @@ func ParseHuaweiCloudError(statusCode int, body string) *HuaweiCloudError {
 			Details:    body,
 			StatusCode: statusCode,
 			Retryable:  false,
+			Hint:       hintFor("Forbidden", ErrorTypePermission),
 		}
 	}
 
@@ -183,7 +342,8 @@ func isRetryableError(errorType ErrorType, code string) bool {
 	switch errorType {
 	case ErrorTypeNetwork, ErrorTypeServer, ErrorTypeThrottle:
 		return true
-	case ErrorTypeAuth, ErrorTypePermission, ErrorTypeValidation, ErrorTypeNotFound:
+	case ErrorTypeAuth, ErrorTypePermission, ErrorTypeValidation, ErrorTypeNotFound,
+		ErrorTypeTaskFailed, ErrorTypeTimeout:
 		return false
 	default:
 		// 特定错误码的重试策略
@@ -254,3 +414,84 @@ func NewServerError(message string) *HuaweiCloudError {
 func NewNotFoundError(resource string) *HuaweiCloudError {
 	return NewError(ErrorTypeNotFound, "NotFound", fmt.Sprintf("资源 '%s' 不存在", resource))
 }
+
+// NewTaskFailedError 创建异步任务失败错误
+func NewTaskFailedError(message string) *HuaweiCloudError {
+	return NewError(ErrorTypeTaskFailed, "TaskFailed", message)
+}
+
+// NewTimeoutError 创建等待超时错误
+func NewTimeoutError(message string) *HuaweiCloudError {
+	return NewError(ErrorTypeTimeout, "Timeout", message)
+}
+
+// NewQuotaExceededError 创建配额不足错误
+// 配额按天重置，立即重试无意义，因此不可重试
+func NewQuotaExceededError(message string) *HuaweiCloudError {
+	return &HuaweiCloudError{
+		Type:      ErrorTypeThrottle,
+		Code:      "QuotaExceeded",
+		Message:   message,
+		Retryable: false,
+	}
+}
+
+// NewDriftDetectedError 创建配置漂移错误，用于 --detect-drift 等检查模式
+func NewDriftDetectedError(message string) *HuaweiCloudError {
+	return NewError(ErrorTypeDrift, "DriftDetected", message)
+}
+
+// NewCertificateExpiringError 创建证书即将过期错误，用于证书过期监控
+func NewCertificateExpiringError(message string) *HuaweiCloudError {
+	return NewError(ErrorTypeCertExpiring, "CertificateExpiring", message)
+}
+
+// Wrap 为错误添加上下文说明，保留原始错误的类型、错误码、请求 ID 和可重试性
+// err 为连接失败、超时等网络错误时包装为网络错误，其他非 HuaweiCloudError 包装为服务器错误；err 为 nil 时返回 nil
+func Wrap(err error, message string) error {
+	if err == nil {
+		return nil
+	}
+
+	var hwErr *HuaweiCloudError
+	if !stderrors.As(err, &hwErr) {
+		var netErr net.Error
+		if stderrors.As(err, &netErr) || stderrors.Is(err, context.DeadlineExceeded) {
+			return newNetworkErrorWithHint(fmt.Sprintf("%s: %v", message, err))
+		}
+		return NewServerError(fmt.Sprintf("%s: %v", message, err))
+	}
+	wrapped := *hwErr
+	wrapped.Message = fmt.Sprintf("%s: %s", message, hwErr.Message)
+	return &wrapped
+}
+
+// AsHuaweiCloudError 返回错误链中的 HuaweiCloudError，其他错误转换为未知错误，便于统一输出
+func AsHuaweiCloudError(err error) *HuaweiCloudError {
+	if err == nil {
+		return nil
+	}
+
+	var hwErr *HuaweiCloudError
+	if stderrors.As(err, &hwErr) {
+		return hwErr
+	}
+	return NewError(ErrorTypeUnknown, "Unknown", err.Error())
+}
+
+// ExitCode 根据错误类型返回进程退出码
+// 通过 errors.As 查找错误链中的 HuaweiCloudError，其他错误返回 ExitCodeGeneral
+func ExitCode(err error) int {
+	if err == nil {
+		return ExitCodeOK
+	}
+
+	var hwErr *HuaweiCloudError
+	if stderrors.As(err, &hwErr) {
+		if code, ok := exitCodes[hwErr.Type]; ok {
+			return code
+		}
+	}
+
+	return ExitCodeGeneral
+}
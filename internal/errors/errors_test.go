@@ -1,9 +1,15 @@
 package errors
 
 import (
+	"context"
+	stderrors "errors"
 	"fmt"
+	"net"
+	"net/url"
 	"strings"
 	"testing"
+
+	"github.com/huaweicloud/huaweicloud-sdk-go-v3/core/sdkerr"
 )
 
 func TestNewError(t *testing.T) {
@@ -146,6 +152,13 @@ func TestErrorClassificationBusinessLogic(t *testing.T) {
 			shouldRetry:  false, // 资源不存在不应该重试
 			description:  "资源不存在需要检查输入",
 		},
+		{
+			name:         "配额不足",
+			errorFunc:    NewQuotaExceededError,
+			expectedCode: "QuotaExceeded",
+			shouldRetry:  false, // 配额按天重置，立即重试无意义
+			description:  "配额不足需要等待配额重置或减少提交数量",
+		},
 	}
 
 	for _, tc := range testCases {
@@ -296,6 +309,7 @@ func TestErrorTypeConsistency(t *testing.T) {
 		{NewNetworkError, ErrorTypeNetwork, "网络错误"},
 		{NewServerError, ErrorTypeServer, "服务器错误"},
 		{NewNotFoundError, ErrorTypeNotFound, "未找到错误"},
+		{NewQuotaExceededError, ErrorTypeThrottle, "配额不足错误"},
 	}
 
 	for _, test := range typeTests {
@@ -323,3 +337,170 @@ func TestNotFoundErrorFormatting(t *testing.T) {
 		t.Errorf("未找到错误代码不正确，期望: NotFound, 实际: %s", err.Code)
 	}
 }
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		err      error
+		expected int
+	}{
+		{"无错误", nil, ExitCodeOK},
+		{"普通错误", fmt.Errorf("boom"), ExitCodeGeneral},
+		{"任务失败", NewTaskFailedError("任务失败"), ExitCodeTaskFailed},
+		{"等待超时", NewTimeoutError("超时"), ExitCodeTimeout},
+		{"配置漂移", NewDriftDetectedError("漂移"), ExitCodeDrift},
+		{"证书即将过期", NewCertificateExpiringError("即将过期"), ExitCodeCertExpiring},
+		{"包装后的任务失败", fmt.Errorf("wrapped: %w", NewTaskFailedError("任务失败")), ExitCodeTaskFailed},
+		{"参数错误", NewValidationError("参数错误"), ExitCodeValidation},
+		{"认证失败", NewAuthError("认证失败"), ExitCodeAuth},
+		{"权限不足", NewPermissionError("权限不足"), ExitCodePermission},
+		{"资源不存在", NewNotFoundError("example.com"), ExitCodeNotFound},
+		{"配额不足", NewQuotaExceededError("配额不足"), ExitCodeThrottle},
+		{"服务器错误", NewServerError("服务器错误"), ExitCodeServer},
+		{"网络错误", NewNetworkError("网络错误"), ExitCodeNetwork},
+		{"HTTP 429", NewHTTPError(429, ""), ExitCodeThrottle},
+		{"未知类型", NewError(ErrorTypeUnknown, "Unknown", "未知"), ExitCodeGeneral},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.expected {
+				t.Errorf("期望退出码 %d，实际为 %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	if Wrap(nil, "查询失败") != nil {
+		t.Error("nil 错误应返回 nil")
+	}
+
+	original := &HuaweiCloudError{Type: ErrorTypeAuth, Code: "APIGW.0301", Message: "认证失败", RequestID: "req-1"}
+	err := Wrap(original, "查询加速域名失败")
+	var hwErr *HuaweiCloudError
+	if !stderrors.As(err, &hwErr) {
+		t.Fatalf("包装后应仍为 HuaweiCloudError: %v", err)
+	}
+	if hwErr.Type != ErrorTypeAuth || hwErr.Code != "APIGW.0301" || hwErr.RequestID != "req-1" || hwErr.Retryable {
+		t.Errorf("应保留原始错误信息: %+v", hwErr)
+	}
+	if hwErr.Message != "查询加速域名失败: 认证失败" || original.Message != "认证失败" {
+		t.Errorf("错误消息错误: %s", hwErr.Message)
+	}
+	if ExitCode(err) != ExitCodeAuth {
+		t.Errorf("期望退出码 %d，实际为 %d", ExitCodeAuth, ExitCode(err))
+	}
+
+	if ExitCode(Wrap(fmt.Errorf("boom"), "查询失败")) != ExitCodeServer {
+		t.Error("普通错误应包装为服务器错误")
+	}
+
+	// 连接失败和超时包装为可重试的网络错误
+	networkErrs := []error{
+		&url.Error{Op: "Get", URL: "https://example.com", Err: &net.OpError{Op: "dial", Err: fmt.Errorf("connection refused")}},
+		fmt.Errorf("下载失败: %w", context.DeadlineExceeded),
+	}
+	for _, e := range networkErrs {
+		wrapped := AsHuaweiCloudError(Wrap(e, "下载日志失败"))
+		if wrapped.Type != ErrorTypeNetwork || !wrapped.Retryable || ExitCode(wrapped) != ExitCodeNetwork {
+			t.Errorf("%v 应包装为网络错误，实际为 %+v", e, wrapped)
+		}
+	}
+}
+
+func TestAsHuaweiCloudError(t *testing.T) {
+	if AsHuaweiCloudError(nil) != nil {
+		t.Error("nil 错误应返回 nil")
+	}
+
+	original := NewValidationError("参数错误")
+	if got := AsHuaweiCloudError(fmt.Errorf("执行失败: %w", original)); got != original {
+		t.Errorf("应返回错误链中的 HuaweiCloudError: %+v", got)
+	}
+
+	got := AsHuaweiCloudError(fmt.Errorf("boom"))
+	if got.Type != ErrorTypeUnknown || got.Message != "boom" || got.Retryable {
+		t.Errorf("普通错误应转换为未知错误: %+v", got)
+	}
+}
+
+func TestParseHuaweiCloudErrorBody(t *testing.T) {
+	err := ParseHuaweiCloudError(400, `{"error": {"error_code": "CDN.0001", "error_msg": "domain_name is invalid"}}`)
+	if err.Type != ErrorTypeValidation || err.Code != "CDN.0001" || err.Message != "domain_name is invalid" || err.Retryable {
+		t.Errorf("解析错误响应失败: %+v", err)
+	}
+	if err.Hint == "" {
+		t.Error("已知错误码应包含处理建议")
+	}
+
+	// 未知错误码按状态码分类
+	err = ParseHuaweiCloudError(503, `{"code": "CDN.9999", "message": "busy"}`)
+	if err.Type != ErrorTypeServer || err.Code != "CDN.9999" || !err.Retryable {
+		t.Errorf("未知错误码应按状态码分类: %+v", err)
+	}
+}
+
+func TestFromSDKError(t *testing.T) {
+	tests := []struct {
+		name      string
+		err       error
+		wantType  ErrorType
+		wantCode  string
+		retryable bool
+	}{
+		{
+			name: "认证失败",
+			err: &sdkerr.ServiceResponseError{StatusCode: 401, ErrorCode: "APIGW.0301",
+				ErrorMessage: "Incorrect IAM authentication information", RequestId: "req-1"},
+			wantType: ErrorTypeAuth, wantCode: "APIGW.0301",
+		},
+		{
+			name:     "未知错误码按状态码分类",
+			err:      &sdkerr.ServiceResponseError{StatusCode: 404, ErrorCode: "CDN.9999", ErrorMessage: "not found"},
+			wantType: ErrorTypeNotFound, wantCode: "CDN.9999",
+		},
+		{
+			name:     "SDK 未识别的响应体",
+			err:      &sdkerr.ServiceResponseError{StatusCode: 502, ErrorMessage: "Bad Gateway"},
+			wantType: ErrorTypeServer, wantCode: "HTTP502", retryable: true,
+		},
+		{
+			name:     "连接错误",
+			err:      sdkerr.NewConnectionError("connection refused"),
+			wantType: ErrorTypeNetwork, wantCode: "NetworkError", retryable: true,
+		},
+		{
+			name:     "请求超时",
+			err:      sdkerr.NewRequestTimeoutError("timeout"),
+			wantType: ErrorTypeNetwork, wantCode: "NetworkError", retryable: true,
+		},
+		{
+			name:     "网络错误",
+			err:      &net.OpError{Op: "dial", Err: fmt.Errorf("no route to host")},
+			wantType: ErrorTypeNetwork, wantCode: "NetworkError", retryable: true,
+		},
+		{
+			name:     "已转换的错误",
+			err:      fmt.Errorf("wrapped: %w", NewNotFoundError("example.com")),
+			wantType: ErrorTypeNotFound, wantCode: "NotFound",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromSDKError(tt.err)
+			if got.Type != tt.wantType || got.Code != tt.wantCode || got.Retryable != tt.retryable {
+				t.Errorf("转换结果错误: %+v", got)
+			}
+		})
+	}
+
+	got := FromSDKError(tests[0].err)
+	if got.StatusCode != 401 || got.RequestID != "req-1" || got.Hint == "" {
+		t.Errorf("应保留状态码和请求 ID 并给出处理建议: %+v", got)
+	}
+	if FromSDKError(nil) != nil {
+		t.Error("nil 错误应返回 nil")
+	}
+}
@@ -0,0 +1,183 @@
+package logx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+// maxTraceBodySize 记录请求和响应内容的最大字节数，超出部分截断
+const maxTraceBodySize = 16 * 1024
+
+// redacted 脱敏后的占位内容
+const redacted = "******"
+
+// httpDebug 是否记录 HTTP 请求详情，由 --debug-http 开启
+var httpDebug atomic.Bool
+
+// sensitiveHeaders 需要脱敏的请求头和响应头
+var sensitiveHeaders = map[string]bool{
+	"authorization":       true,
+	"proxy-authorization": true,
+	"x-security-token":    true,
+	"x-auth-token":        true,
+	"x-subject-token":     true,
+	"cookie":              true,
+	"set-cookie":          true,
+}
+
+var (
+	// sensitiveKeyRegexp 需要脱敏的查询参数和请求头名称，包括 AK/SK、密码、令牌、签名和证书私钥
+	sensitiveKeyRegexp = regexp.MustCompile(`(?i)(?:secret|password|passwd|token|signature|credential|private_?key|access_?key|^ak$|^sk$)`)
+	// sensitiveJSONRegexp 匹配值为字符串的敏感 JSON 字段，内容被截断时也能脱敏
+	sensitiveJSONRegexp = regexp.MustCompile(`(?i)("[^"]*(?:secret|password|passwd|token|signature|credential|private_?key|access_?key)[^"]*"|"ak"|"sk")(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	// privateKeyRegexp 匹配 PEM 格式的私钥
+	privateKeyRegexp = regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?(?:-----END [A-Z ]*PRIVATE KEY-----|$)`)
+)
+
+// SetHTTPDebug 设置是否记录 HTTP 请求详情
+func SetHTTPDebug(enabled bool) {
+	httpDebug.Store(enabled)
+}
+
+// HTTPDebug 返回是否记录 HTTP 请求详情
+func HTTPDebug() bool {
+	return httpDebug.Load()
+}
+
+// HTTPTransport 开启 HTTP 调试时返回记录请求详情的 RoundTripper，否则原样返回 next
+// next 为 nil 时使用 http.DefaultTransport
+func HTTPTransport(next http.RoundTripper) http.RoundTripper {
+	if !HTTPDebug() {
+		return next
+	}
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &tracingTransport{next: next}
+}
+
+// tracingTransport 以调试级别记录每个请求的方法、URL、请求头、内容和耗时，敏感信息脱敏
+type tracingTransport struct {
+	next http.RoundTripper
+}
+
+// RoundTrip 实现 http.RoundTripper
+func (t *tracingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	reqURL := redactURL(req.URL)
+	if req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req = req.Clone(req.Context())
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		Debug("HTTP 请求", "method", req.Method, "url", reqURL,
+			"headers", redactHeaders(req.Header), "body", formatBody(req.Header.Get("Content-Type"), body, false))
+	} else {
+		Debug("HTTP 请求", "method", req.Method, "url", reqURL, "headers", redactHeaders(req.Header))
+	}
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	elapsed := time.Since(start).Milliseconds()
+	if err != nil {
+		Debug("HTTP 请求失败", "method", req.Method, "url", reqURL, "duration_ms", elapsed, KeyError, err)
+		return nil, err
+	}
+
+	// 只读取用于记录的部分内容，再与剩余内容拼接，避免大文件下载全部读入内存
+	prefix, readErr := io.ReadAll(io.LimitReader(resp.Body, maxTraceBodySize+1))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(prefix), resp.Body), resp.Body}
+	truncated := len(prefix) > maxTraceBodySize
+	if truncated {
+		prefix = prefix[:maxTraceBodySize]
+	}
+	if readErr != nil {
+		truncated = true
+	}
+
+	Debug("HTTP 响应", "method", req.Method, "url", reqURL, "status", resp.StatusCode, "duration_ms", elapsed,
+		KeyRequestID, resp.Header.Get("X-Request-Id"), "headers", redactHeaders(resp.Header),
+		"body", formatBody(resp.Header.Get("Content-Type"), prefix, truncated))
+	return resp, nil
+}
+
+// redactURL 返回查询参数中的签名、AK 等敏感信息脱敏后的 URL
+func redactURL(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	if u.RawQuery == "" {
+		return u.String()
+	}
+
+	query := u.Query()
+	for key := range query {
+		if sensitiveKeyRegexp.MatchString(key) {
+			query.Set(key, redacted)
+		}
+	}
+	clone := *u
+	clone.RawQuery = query.Encode()
+	return clone.String()
+}
+
+// redactHeaders 返回敏感头脱敏后的请求头，多个值以逗号连接
+func redactHeaders(header http.Header) map[string]string {
+	result := make(map[string]string, len(header))
+	for key, values := range header {
+		if sensitiveHeaders[strings.ToLower(key)] || sensitiveKeyRegexp.MatchString(key) {
+			result[key] = redacted
+			continue
+		}
+		result[key] = strings.Join(values, ", ")
+	}
+	return result
+}
+
+// formatBody 返回脱敏后的请求或响应内容，二进制内容只记录长度
+func formatBody(contentType string, body []byte, truncated bool) string {
+	if len(body) == 0 {
+		return ""
+	}
+	if !isTextContent(contentType, body) {
+		return fmt.Sprintf("<%d 字节二进制内容>", len(body))
+	}
+
+	text := redactBody(string(body))
+	if truncated {
+		text += "...(已截断)"
+	}
+	return text
+}
+
+// redactBody 脱敏 JSON 中的 AK/SK、密码、令牌字段和 PEM 格式的私钥
+func redactBody(body string) string {
+	body = privateKeyRegexp.ReplaceAllString(body, redacted)
+	return sensitiveJSONRegexp.ReplaceAllString(body, `$1$2"`+redacted+`"`)
+}
+
+// isTextContent 根据 Content-Type 和内容判断是否为文本
+func isTextContent(contentType string, body []byte) bool {
+	contentType = strings.ToLower(contentType)
+	for _, textType := range []string{"json", "xml", "text/", "x-www-form-urlencoded"} {
+		if strings.Contains(contentType, textType) {
+			return true
+		}
+	}
+	if contentType != "" {
+		return false
+	}
+	return strings.HasPrefix(http.DetectContentType(body), "text/")
+}
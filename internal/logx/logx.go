@@ -1,9 +1,14 @@
 package logx
 
 import (
+	"context"
 	"fmt"
-	"log"
+	"io"
+	"log/slog"
 	"os"
+	"strings"
+	"sync"
+	"time"
 )
 
 // LogLevel 日志级别
@@ -16,14 +21,106 @@ const (
 	ERROR
 )
 
+// DefaultLevel 默认日志级别，只输出警告和错误，避免诊断信息干扰命令输出
+const DefaultLevel = WARN
+
+// 日志格式
+const (
+	FormatText = "text"
+	FormatJSON = "json"
+)
+
+// Formats 支持的日志格式
+var Formats = []string{FormatText, FormatJSON}
+
+// 结构化日志中的通用字段名
+const (
+	KeyCommand   = "command"
+	KeyRequestID = "request_id"
+	KeyError     = "error"
+)
+
+// slogLevels 日志级别与 slog 级别的对应关系
+var slogLevels = map[LogLevel]slog.Level{
+	DEBUG: slog.LevelDebug,
+	INFO:  slog.LevelInfo,
+	WARN:  slog.LevelWarn,
+	ERROR: slog.LevelError,
+}
+
+// output 日志输出目标，所有级别的日志都写入标准错误，标准输出只保留命令结果
+var output io.Writer = os.Stderr
+
 var (
-	currentLevel = INFO
-	debugLogger  = log.New(os.Stdout, "[DEBUG] ", log.LstdFlags)
-	infoLogger   = log.New(os.Stdout, "[INFO]  ", log.LstdFlags)
-	warnLogger   = log.New(os.Stdout, "[WARN]  ", log.LstdFlags)
-	errorLogger  = log.New(os.Stderr, "[ERROR] ", log.LstdFlags)
+	mu           sync.Mutex
+	currentLevel = DefaultLevel
+	levelVar     = newLevelVar(DefaultLevel)
+	format       = FormatText
+	command      string
+	logFile      *rotatingFile // 设置日志文件后，所有级别的日志都会写入文件
+	logger       = newLogger()
 )
 
+// newLevelVar 创建指定级别的 slog.LevelVar
+func newLevelVar(level LogLevel) *slog.LevelVar {
+	v := new(slog.LevelVar)
+	v.Set(slogLevels[level])
+	return v
+}
+
+// newLogger 按当前的格式、输出目标和命令名称创建 logger，调用方需持有 mu 或处于初始化阶段
+func newLogger() *slog.Logger {
+	handler := newHandler(output, levelVar)
+	if logFile != nil {
+		handler = multiHandler{handler, newHandler(logFile, slog.LevelDebug)}
+	}
+
+	l := slog.New(handler)
+	if command != "" {
+		l = l.With(KeyCommand, command)
+	}
+	return l
+}
+
+// newHandler 按当前格式创建写入 w 的处理器
+func newHandler(w io.Writer, level slog.Leveler) slog.Handler {
+	if format == FormatJSON {
+		return slog.NewJSONHandler(w, &slog.HandlerOptions{Level: level, ReplaceAttr: replaceJSONAttr})
+	}
+	return &textHandler{w: w, mu: &sync.Mutex{}, level: level}
+}
+
+// replaceJSONAttr 将 slog 的内置字段重命名为 timestamp、level、message，级别使用小写
+func replaceJSONAttr(groups []string, a slog.Attr) slog.Attr {
+	if len(groups) > 0 {
+		return a
+	}
+	if isEmptyRequestID(a) {
+		return slog.Attr{}
+	}
+	switch a.Key {
+	case slog.TimeKey:
+		a.Key = "timestamp"
+	case slog.LevelKey:
+		a.Value = slog.StringValue(strings.ToLower(a.Value.String()))
+	case slog.MessageKey:
+		a.Key = "message"
+	}
+	return a
+}
+
+// isEmptyRequestID 判断是否为空的 request_id 字段，请求未到达服务端时没有请求 ID，不输出该字段
+func isEmptyRequestID(a slog.Attr) bool {
+	return a.Key == KeyRequestID && a.Value.Resolve().String() == ""
+}
+
+// rebuild 在配置变化后重新创建 logger
+func rebuild() {
+	mu.Lock()
+	defer mu.Unlock()
+	logger = newLogger()
+}
+
 // SetLevel 设置日志级别
 func SetLevel(level string) {
 	switch level {
@@ -38,73 +135,152 @@ func SetLevel(level string) {
 	default:
 		currentLevel = INFO
 	}
+	levelVar.Set(slogLevels[currentLevel])
+}
+
+// SetOutput 设置日志输出目标，默认为标准错误
+func SetOutput(w io.Writer) {
+	mu.Lock()
+	output = w
+	mu.Unlock()
+	rebuild()
+}
+
+// SetFormat 设置日志格式，支持 text 和 json
+func SetFormat(f string) error {
+	f = strings.ToLower(strings.TrimSpace(f))
+	if f == "" {
+		f = FormatText
+	}
+	if f != FormatText && f != FormatJSON {
+		return fmt.Errorf("不支持的日志格式: %s，支持的格式: %s", f, strings.Join(Formats, ", "))
+	}
+
+	mu.Lock()
+	format = f
+	mu.Unlock()
+	rebuild()
+	return nil
+}
+
+// SetCommand 设置当前执行的命令，结构化日志的每条记录都会带上 command 字段
+func SetCommand(name string) {
+	mu.Lock()
+	command = name
+	mu.Unlock()
+	rebuild()
+}
+
+// SetFile 将完整的调试级别日志同时写入文件，终端仍按当前级别输出
+// 文件按大小轮转并保留指定数量的历史文件，再次调用时关闭之前的文件
+func SetFile(opts FileOptions) error {
+	file, err := openRotatingFile(opts)
+	if err != nil {
+		return err
+	}
+
+	mu.Lock()
+	previous := logFile
+	logFile = file
+	mu.Unlock()
+	rebuild()
+
+	if previous != nil {
+		previous.Close()
+	}
+	return nil
 }
 
-// Debug 输出调试日志
-func Debug(v ...interface{}) {
-	if currentLevel <= DEBUG {
-		debugLogger.Print(v...)
+// Close 关闭日志文件，之后的日志只输出到终端
+func Close() error {
+	mu.Lock()
+	file := logFile
+	logFile = nil
+	mu.Unlock()
+	rebuild()
+
+	if file == nil {
+		return nil
+	}
+	return file.Close()
+}
+
+// enabled 判断指定级别的日志是否需要输出，设置日志文件后所有级别都会写入文件
+func enabled(level LogLevel) bool {
+	if currentLevel <= level {
+		return true
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return logFile != nil
+}
+
+// log 按级别输出一条日志，args 为交替的键和值
+func log(level LogLevel, msg string, args ...interface{}) {
+	if !enabled(level) {
+		return
 	}
+	mu.Lock()
+	l := logger
+	mu.Unlock()
+	l.Log(context.Background(), slogLevels[level], msg, args...)
+}
+
+// Debug 输出调试日志，args 为交替的键和值，例如 Debug("创建客户端", "region", region)
+func Debug(msg string, args ...interface{}) {
+	log(DEBUG, msg, args...)
 }
 
 // Debugf 格式化输出调试日志
 func Debugf(format string, v ...interface{}) {
-	if currentLevel <= DEBUG {
-		debugLogger.Printf(format, v...)
+	if enabled(DEBUG) {
+		log(DEBUG, fmt.Sprintf(format, v...))
 	}
 }
 
-// Info 输出信息日志
-func Info(v ...interface{}) {
-	if currentLevel <= INFO {
-		infoLogger.Print(v...)
-	}
+// Info 输出信息日志，args 为交替的键和值
+func Info(msg string, args ...interface{}) {
+	log(INFO, msg, args...)
 }
 
 // Infof 格式化输出信息日志
 func Infof(format string, v ...interface{}) {
-	if currentLevel <= INFO {
-		infoLogger.Printf(format, v...)
+	if enabled(INFO) {
+		log(INFO, fmt.Sprintf(format, v...))
 	}
 }
 
-// Warn 输出警告日志
-func Warn(v ...interface{}) {
-	if currentLevel <= WARN {
-		warnLogger.Print(v...)
-	}
+// Warn 输出警告日志，args 为交替的键和值
+func Warn(msg string, args ...interface{}) {
+	log(WARN, msg, args...)
 }
 
 // Warnf 格式化输出警告日志
 func Warnf(format string, v ...interface{}) {
-	if currentLevel <= WARN {
-		warnLogger.Printf(format, v...)
+	if enabled(WARN) {
+		log(WARN, fmt.Sprintf(format, v...))
 	}
 }
 
-// Error 输出错误日志
-func Error(v ...interface{}) {
-	if currentLevel <= ERROR {
-		errorLogger.Print(v...)
-	}
+// Error 输出错误日志，args 为交替的键和值
+func Error(msg string, args ...interface{}) {
+	log(ERROR, msg, args...)
 }
 
 // Errorf 格式化输出错误日志
 func Errorf(format string, v ...interface{}) {
-	if currentLevel <= ERROR {
-		errorLogger.Printf(format, v...)
-	}
+	log(ERROR, fmt.Sprintf(format, v...))
 }
 
 // Fatal 输出致命错误日志并退出
 func Fatal(v ...interface{}) {
-	errorLogger.Print(v...)
+	log(ERROR, fmt.Sprint(v...))
 	os.Exit(1)
 }
 
 // Fatalf 格式化输出致命错误日志并退出
 func Fatalf(format string, v ...interface{}) {
-	errorLogger.Printf(format, v...)
+	log(ERROR, fmt.Sprintf(format, v...))
 	os.Exit(1)
 }
 
@@ -117,3 +293,125 @@ func Printf(format string, v ...interface{}) {
 func Println(v ...interface{}) {
 	fmt.Println(v...)
 }
+
+// textPrefixes 文本格式下各级别的前缀
+var textPrefixes = map[slog.Level]string{
+	slog.LevelDebug: "[DEBUG] ",
+	slog.LevelInfo:  "[INFO]  ",
+	slog.LevelWarn:  "[WARN]  ",
+	slog.LevelError: "[ERROR] ",
+}
+
+// textHandler 文本格式的日志处理器，输出形如 "[INFO]  2006/01/02 15:04:05 消息 key=value"
+// command 字段只用于结构化日志，文本格式下不输出
+type textHandler struct {
+	w      io.Writer
+	mu     *sync.Mutex
+	level  slog.Leveler
+	attrs  []slog.Attr
+	prefix string // 分组前缀
+}
+
+// Enabled 实现 slog.Handler
+func (h *textHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return level >= h.level.Level()
+}
+
+// Handle 实现 slog.Handler
+func (h *textHandler) Handle(_ context.Context, r slog.Record) error {
+	var b strings.Builder
+	b.WriteString(textPrefixes[r.Level])
+	t := r.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	b.WriteString(t.Format("2006/01/02 15:04:05 "))
+	b.WriteString(r.Message)
+
+	writeAttr := func(a slog.Attr) {
+		if a.Key == KeyCommand || a.Equal(slog.Attr{}) || isEmptyRequestID(a) {
+			return
+		}
+		fmt.Fprintf(&b, " %s=%v", a.Key, a.Value.Resolve())
+	}
+	for _, a := range h.attrs {
+		writeAttr(a)
+	}
+	r.Attrs(func(a slog.Attr) bool {
+		a.Key = h.prefix + a.Key
+		writeAttr(a)
+		return true
+	})
+	b.WriteString("\n")
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	_, err := io.WriteString(h.w, b.String())
+	return err
+}
+
+// WithAttrs 实现 slog.Handler
+func (h *textHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	clone := *h
+	clone.attrs = append([]slog.Attr{}, h.attrs...)
+	for _, a := range attrs {
+		a.Key = h.prefix + a.Key
+		clone.attrs = append(clone.attrs, a)
+	}
+	return &clone
+}
+
+// WithGroup 实现 slog.Handler
+func (h *textHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	clone := *h
+	clone.prefix = h.prefix + name + "."
+	return &clone
+}
+
+// multiHandler 将日志记录分发给多个处理器，各处理器按自己的级别过滤
+type multiHandler []slog.Handler
+
+// Enabled 实现 slog.Handler
+func (m multiHandler) Enabled(ctx context.Context, level slog.Level) bool {
+	for _, h := range m {
+		if h.Enabled(ctx, level) {
+			return true
+		}
+	}
+	return false
+}
+
+// Handle 实现 slog.Handler
+func (m multiHandler) Handle(ctx context.Context, r slog.Record) error {
+	var firstErr error
+	for _, h := range m {
+		if !h.Enabled(ctx, r.Level) {
+			continue
+		}
+		if err := h.Handle(ctx, r.Clone()); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// WithAttrs 实现 slog.Handler
+func (m multiHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	handlers := make(multiHandler, len(m))
+	for i, h := range m {
+		handlers[i] = h.WithAttrs(attrs)
+	}
+	return handlers
+}
+
+// WithGroup 实现 slog.Handler
+func (m multiHandler) WithGroup(name string) slog.Handler {
+	handlers := make(multiHandler, len(m))
+	for i, h := range m {
+		handlers[i] = h.WithGroup(name)
+	}
+	return handlers
+}
@@ -1,6 +1,10 @@
 package logx
 
 import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
 	"testing"
 )
 
@@ -51,3 +55,124 @@ func TestLogLevels(t *testing.T) {
 	Info("这条信息日志不应该显示")
 	Error("这条错误日志应该显示")
 }
+
+func TestSetOutput(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	t.Cleanup(func() {
+		SetOutput(os.Stderr)
+		SetLevel("warn")
+	})
+
+	SetLevel("warn")
+	Info("默认级别不应输出")
+	Warn("警告日志")
+	if strings.Contains(buf.String(), "默认级别不应输出") || !strings.HasPrefix(buf.String(), "[WARN]") || !strings.Contains(buf.String(), "警告日志") {
+		t.Errorf("warn 级别输出错误: %q", buf.String())
+	}
+
+	buf.Reset()
+	SetLevel("debug")
+	Debug("调试日志")
+	Info("信息日志")
+	if !strings.Contains(buf.String(), "调试日志") || !strings.Contains(buf.String(), "[INFO]") {
+		t.Errorf("所有级别的日志都应写入设置的输出: %q", buf.String())
+	}
+}
+
+func TestDefaultLevel(t *testing.T) {
+	if DefaultLevel != WARN {
+		t.Errorf("默认日志级别应为 WARN，实际为 %v", DefaultLevel)
+	}
+}
+
+func TestJSONFormat(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	t.Cleanup(func() {
+		SetOutput(os.Stderr)
+		SetFormat(FormatText)
+		SetCommand("")
+		SetLevel("warn")
+	})
+
+	if err := SetFormat("json"); err != nil {
+		t.Fatalf("设置日志格式失败: %v", err)
+	}
+	SetCommand("hwcctl cdn refresh")
+	SetLevel("info")
+	Info("刷新任务创建成功", "task_id", "task-1", KeyRequestID, "req-1")
+	Errorf("刷新失败: %s", "boom")
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("每条日志应输出一行 JSON，实际输出: %q", buf.String())
+	}
+	var record map[string]interface{}
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("日志应为合法 JSON: %v", err)
+	}
+	for key, want := range map[string]string{
+		"level":      "info",
+		"message":    "刷新任务创建成功",
+		KeyCommand:   "hwcctl cdn refresh",
+		KeyRequestID: "req-1",
+		"task_id":    "task-1",
+	} {
+		if record[key] != want {
+			t.Errorf("字段 %s 期望为 %q，实际为 %v", key, want, record[key])
+		}
+	}
+	if _, ok := record["timestamp"]; !ok {
+		t.Errorf("日志应包含 timestamp 字段: %v", record)
+	}
+	if !strings.Contains(lines[1], `"level":"error"`) || !strings.Contains(lines[1], `"message":"刷新失败: boom"`) {
+		t.Errorf("格式化日志输出错误: %s", lines[1])
+	}
+
+	if err := SetFormat("xml"); err == nil {
+		t.Error("不支持的日志格式应返回错误")
+	}
+}
+
+func TestTextFormatFields(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	SetCommand("hwcctl cdn refresh")
+	t.Cleanup(func() {
+		SetOutput(os.Stderr)
+		SetCommand("")
+		SetLevel("warn")
+	})
+
+	Warn("配额不足", "remaining", 10)
+	if !strings.HasPrefix(buf.String(), "[WARN]") || !strings.HasSuffix(buf.String(), "配额不足 remaining=10\n") {
+		t.Errorf("文本格式输出错误: %q", buf.String())
+	}
+	if strings.Contains(buf.String(), KeyCommand) {
+		t.Errorf("文本格式不应输出 command 字段: %q", buf.String())
+	}
+}
+
+func TestEmptyRequestIDOmitted(t *testing.T) {
+	var buf bytes.Buffer
+	SetOutput(&buf)
+	t.Cleanup(func() {
+		SetOutput(os.Stderr)
+		SetFormat(FormatText)
+	})
+
+	Warn("刷新失败", KeyRequestID, "")
+	if strings.Contains(buf.String(), KeyRequestID) {
+		t.Errorf("文本格式不应输出空的 request_id: %q", buf.String())
+	}
+
+	buf.Reset()
+	SetFormat(FormatJSON)
+	Warn("刷新失败", KeyRequestID, "")
+	Warn("刷新失败", KeyRequestID, "req-1")
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 2 || strings.Contains(lines[0], KeyRequestID) || !strings.Contains(lines[1], `"request_id":"req-1"`) {
+		t.Errorf("JSON 格式应只在有请求 ID 时输出 request_id: %q", buf.String())
+	}
+}
@@ -0,0 +1,126 @@
+package logx
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// 日志文件轮转的默认值
+const (
+	DefaultMaxFileSize    = 10 * 1024 * 1024 // 单个日志文件的最大字节数
+	DefaultMaxFileBackups = 5                // 保留的历史日志文件数量
+)
+
+// FileOptions 日志文件配置
+type FileOptions struct {
+	Path string
+	// MaxSize 单个文件的最大字节数，写入后超过该大小时轮转，小于等于 0 时使用 DefaultMaxFileSize
+	MaxSize int64
+	// MaxBackups 保留的历史文件数量，历史文件依次命名为 PATH.1、PATH.2 ...，小于等于 0 时使用 DefaultMaxFileBackups
+	MaxBackups int
+}
+
+// rotatingFile 按大小轮转的日志文件，实现 io.WriteCloser
+type rotatingFile struct {
+	mu         sync.Mutex
+	path       string
+	maxSize    int64
+	maxBackups int
+	file       *os.File
+	size       int64
+}
+
+// openRotatingFile 以追加方式打开日志文件，必要时创建所在目录
+func openRotatingFile(opts FileOptions) (*rotatingFile, error) {
+	r := &rotatingFile{
+		path:       opts.Path,
+		maxSize:    opts.MaxSize,
+		maxBackups: opts.MaxBackups,
+	}
+	if r.maxSize <= 0 {
+		r.maxSize = DefaultMaxFileSize
+	}
+	if r.maxBackups <= 0 {
+		r.maxBackups = DefaultMaxFileBackups
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o700); err != nil {
+		return nil, fmt.Errorf("创建日志目录失败: %w", err)
+	}
+	if err := r.open(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// open 打开当前日志文件并记录已有大小，日志可能包含请求详情，只允许当前用户读写
+func (r *rotatingFile) open() error {
+	file, err := os.OpenFile(r.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o600)
+	if err != nil {
+		return fmt.Errorf("打开日志文件失败: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("读取日志文件信息失败: %w", err)
+	}
+	r.file = file
+	r.size = info.Size()
+	return nil
+}
+
+// Write 写入日志，当前文件写满时先轮转
+func (r *rotatingFile) Write(p []byte) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return 0, os.ErrClosed
+	}
+	if r.size > 0 && r.size+int64(len(p)) > r.maxSize {
+		if err := r.rotate(); err != nil {
+			return 0, err
+		}
+	}
+
+	n, err := r.file.Write(p)
+	r.size += int64(n)
+	return n, err
+}
+
+// rotate 将 PATH.N 依次重命名为 PATH.N+1，当前文件重命名为 PATH.1，超出保留数量的文件被删除
+func (r *rotatingFile) rotate() error {
+	if err := r.file.Close(); err != nil {
+		return err
+	}
+	r.file = nil
+
+	os.Remove(r.backupName(r.maxBackups))
+	for i := r.maxBackups - 1; i >= 1; i-- {
+		os.Rename(r.backupName(i), r.backupName(i+1))
+	}
+	if err := os.Rename(r.path, r.backupName(1)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("轮转日志文件失败: %w", err)
+	}
+	return r.open()
+}
+
+// backupName 返回第 i 个历史文件的路径
+func (r *rotatingFile) backupName(i int) string {
+	return fmt.Sprintf("%s.%d", r.path, i)
+}
+
+// Close 关闭日志文件
+func (r *rotatingFile) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file = nil
+	return err
+}
@@ -0,0 +1,94 @@
+package logx
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRotatingFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "logs", "hwcctl.log")
+	file, err := openRotatingFile(FileOptions{Path: path, MaxSize: 10, MaxBackups: 2})
+	if err != nil {
+		t.Fatalf("打开日志文件失败: %v", err)
+	}
+	defer file.Close()
+
+	for _, line := range []string{"run-1\n", "run-2\n", "run-3\n", "run-4\n"} {
+		if _, err := file.Write([]byte(line)); err != nil {
+			t.Fatalf("写入日志失败: %v", err)
+		}
+	}
+
+	expected := map[string]string{
+		path:        "run-4\n",
+		path + ".1": "run-3\n",
+		path + ".2": "run-2\n",
+	}
+	for name, want := range expected {
+		got, err := os.ReadFile(name)
+		if err != nil || string(got) != want {
+			t.Errorf("文件 %s 内容期望为 %q，实际为 %q (%v)", name, want, got, err)
+		}
+	}
+	if _, err := os.Stat(path + ".3"); !os.IsNotExist(err) {
+		t.Error("超出保留数量的历史文件应被删除")
+	}
+
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o600 {
+		t.Errorf("日志文件权限应为 0600: %v", err)
+	}
+}
+
+func TestRotatingFileAppend(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hwcctl.log")
+	os.WriteFile(path, []byte("previous\n"), 0o600)
+
+	file, err := openRotatingFile(FileOptions{Path: path})
+	if err != nil {
+		t.Fatalf("打开日志文件失败: %v", err)
+	}
+	file.Write([]byte("current\n"))
+	file.Close()
+
+	got, _ := os.ReadFile(path)
+	if string(got) != "previous\ncurrent\n" {
+		t.Errorf("未超过大小限制时应追加写入，实际内容: %q", got)
+	}
+	if _, err := file.Write([]byte("closed\n")); err == nil {
+		t.Error("关闭后写入应返回错误")
+	}
+}
+
+func TestSetFile(t *testing.T) {
+	var terminal strings.Builder
+	SetOutput(&terminal)
+	SetLevel("warn")
+	path := filepath.Join(t.TempDir(), "hwcctl.log")
+	if err := SetFile(FileOptions{Path: path}); err != nil {
+		t.Fatalf("设置日志文件失败: %v", err)
+	}
+	t.Cleanup(func() {
+		Close()
+		SetOutput(os.Stderr)
+	})
+
+	Debugf("调试日志: %s", "trace")
+	Warn("警告日志")
+	if err := Close(); err != nil {
+		t.Fatalf("关闭日志文件失败: %v", err)
+	}
+	Debug("关闭后的调试日志")
+
+	content, _ := os.ReadFile(path)
+	if !strings.Contains(string(content), "调试日志: trace") || !strings.Contains(string(content), "警告日志") {
+		t.Errorf("日志文件应包含所有级别的日志: %q", content)
+	}
+	if strings.Contains(string(content), "关闭后的调试日志") {
+		t.Error("关闭后不应继续写入日志文件")
+	}
+	if strings.Contains(terminal.String(), "调试日志") || !strings.Contains(terminal.String(), "警告日志") {
+		t.Errorf("终端应保持原有日志级别: %q", terminal.String())
+	}
+}
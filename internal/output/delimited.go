@@ -0,0 +1,358 @@
+package output
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+)
+
+// CSV、TSV 表头使用的字段名来源
+const (
+	HeaderStyleTable = "table" // 使用结构体的 table 标签，与表格输出一致
+	HeaderStyleJSON  = "json"  // 使用 JSON 字段名，与 --query 表达式一致
+)
+
+// HeaderStyles 支持的表头字段名来源
+var HeaderStyles = []string{HeaderStyleTable, HeaderStyleJSON}
+
+// listSeparator 切片展开为单元格时元素之间的分隔符
+const listSeparator = ";"
+
+// timeType time.Time 作为单个值输出，不展开字段
+var timeType = reflect.TypeOf(time.Time{})
+
+var (
+	// defaultNoHeaders 通过 --no-headers 指定，CSV、TSV 输出不包含表头行
+	defaultNoHeaders bool
+	// defaultHeaderStyle 通过 --header-style 指定的表头字段名来源
+	defaultHeaderStyle = HeaderStyleTable
+)
+
+// SetNoHeaders 设置 CSV、TSV 输出是否省略表头行
+func SetNoHeaders(noHeaders bool) {
+	defaultNoHeaders = noHeaders
+}
+
+// SetHeaderStyle 设置 CSV、TSV 表头使用的字段名来源，为空时使用 table 标签
+func SetHeaderStyle(style string) error {
+	style = strings.ToLower(strings.TrimSpace(style))
+	if style == "" {
+		style = HeaderStyleTable
+	}
+	if style != HeaderStyleTable && style != HeaderStyleJSON {
+		return hwErrors.NewValidationError(fmt.Sprintf("不支持的表头样式: %s，支持: %s",
+			style, strings.Join(HeaderStyles, ", ")))
+	}
+	defaultHeaderStyle = style
+	return nil
+}
+
+// IsDelimited 判断是否为 csv、tsv 等分隔符格式
+func (f *Formatter) IsDelimited() bool {
+	return f.format == FormatCSV || f.format == FormatTSV
+}
+
+// WriteRecords 按当前的 CSV 或 TSV 格式写入表头和数据行，指定 --no-headers 时省略表头
+// 用于统计数据等列由命令自行决定的输出
+func (f *Formatter) WriteRecords(w io.Writer, header []string, records [][]string) error {
+	writer := csv.NewWriter(w)
+	if f.format == FormatTSV {
+		writer.Comma = '\t'
+	}
+	if !f.noHeaders {
+		if err := writer.Write(header); err != nil {
+			return err
+		}
+	}
+	for _, record := range records {
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// printDelimited 将数据展开为行和列后输出为 CSV 或 TSV
+// 切片的每个元素为一行，结构体和 map 为一行，嵌套字段的列名以 "." 连接
+func (f *Formatter) printDelimited(data interface{}) error {
+	v := indirect(reflect.ValueOf(data))
+
+	var items []reflect.Value
+	switch {
+	case !v.IsValid():
+	case (v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8) || v.Kind() == reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			items = append(items, v.Index(i))
+		}
+	default:
+		items = append(items, v)
+	}
+
+	var header []string
+	seen := map[string]bool{}
+	structRows := true
+	rows := make([]map[string]string, 0, len(items))
+	addColumns := func(row *flatRow) {
+		for _, key := range row.keys {
+			if !seen[key] {
+				seen[key] = true
+				header = append(header, key)
+			}
+		}
+	}
+	for _, item := range items {
+		row := f.newFlatRow()
+		item = indirect(item)
+		if t := rowStructType(v, item); t != nil {
+			row.flattenStruct("", item, t)
+		} else {
+			structRows = false
+			row.flatten("", item)
+		}
+		addColumns(row)
+		rows = append(rows, row.values)
+	}
+	// 空的结构体切片仍按类型输出表头
+	if len(items) == 0 {
+		if t := rowStructType(v, reflect.Value{}); t != nil {
+			row := f.newFlatRow()
+			row.flattenStruct("", reflect.Value{}, t)
+			addColumns(row)
+		}
+	}
+	if len(header) == 0 {
+		return nil
+	}
+	// map 和查询结果的键没有固定顺序，按字母排序保证每次输出的列一致
+	if !structRows {
+		sort.Strings(header)
+	}
+
+	records := make([][]string, 0, len(rows))
+	for _, row := range rows {
+		record := make([]string, len(header))
+		for i, key := range header {
+			record[i] = row[key]
+		}
+		records = append(records, record)
+	}
+	return f.WriteRecords(os.Stdout, header, records)
+}
+
+// newFlatRow 按当前的表头样式创建空行
+func (f *Formatter) newFlatRow() *flatRow {
+	return &flatRow{values: map[string]string{}, jsonNames: f.headerStyle == HeaderStyleJSON}
+}
+
+// flatRow 展开后的一行数据，keys 保持列的出现顺序
+type flatRow struct {
+	keys      []string
+	values    map[string]string
+	jsonNames bool
+}
+
+// set 记录一列的值
+func (r *flatRow) set(key, value string) {
+	if key == "" {
+		key = "value"
+	}
+	if _, ok := r.values[key]; !ok {
+		r.keys = append(r.keys, key)
+	}
+	r.values[key] = value
+}
+
+// flatten 展开任意值，结构体和 map 的字段展开为多列，其余值为一列
+func (r *flatRow) flatten(prefix string, v reflect.Value) {
+	v = indirect(v)
+	if !v.IsValid() {
+		r.set(prefix, "")
+		return
+	}
+
+	switch {
+	case v.Type() == timeType:
+		r.set(prefix, formatCell(v))
+	case v.Kind() == reflect.Struct:
+		r.flattenStruct(prefix, v, v.Type())
+	case v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String:
+		keys := make([]string, 0, v.Len())
+		for _, key := range v.MapKeys() {
+			keys = append(keys, key.String())
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			r.flatten(joinKey(prefix, key), v.MapIndex(reflect.ValueOf(key).Convert(v.Type().Key())))
+		}
+	default:
+		r.set(prefix, formatCell(v))
+	}
+}
+
+// flattenStruct 按字段顺序展开结构体，值为 nil 指针时按类型输出空列，保证每行的列一致
+func (r *flatRow) flattenStruct(prefix string, v reflect.Value, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name, skip := r.columnName(field)
+		if skip {
+			continue
+		}
+
+		var fieldValue reflect.Value
+		if v.IsValid() {
+			fieldValue = indirect(v.Field(i))
+		}
+
+		fieldType := field.Type
+		for fieldType.Kind() == reflect.Ptr {
+			fieldType = fieldType.Elem()
+		}
+		// 匿名嵌入的结构体字段直接展开到当前层级
+		key := joinKey(prefix, name)
+		if field.Anonymous && fieldType.Kind() == reflect.Struct {
+			key = prefix
+		}
+
+		if fieldType.Kind() == reflect.Struct && fieldType != timeType {
+			r.flattenStruct(key, fieldValue, fieldType)
+			continue
+		}
+		if !fieldValue.IsValid() {
+			r.set(key, "")
+			continue
+		}
+		r.flatten(key, fieldValue)
+	}
+}
+
+// columnName 返回字段的列名，优先使用 table 标签或 JSON 字段名，json:"-" 的字段不输出
+func (r *flatRow) columnName(field reflect.StructField) (string, bool) {
+	jsonName, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	if jsonName == "-" {
+		return "", true
+	}
+	if tableName := field.Tag.Get("table"); tableName != "" && !r.jsonNames {
+		return tableName, false
+	}
+	if jsonName != "" {
+		return jsonName, false
+	}
+	return field.Name, false
+}
+
+// formatCell 将单个值格式化为单元格内容
+// 数字不使用科学计数法，时间使用 RFC 3339，简单类型的切片以 ";" 连接，其余复杂类型输出为紧凑的 JSON
+func formatCell(v reflect.Value) string {
+	v = indirect(v)
+	if !v.IsValid() {
+		return ""
+	}
+	if t, ok := v.Interface().(time.Time); ok {
+		if t.IsZero() {
+			return ""
+		}
+		return t.Format(time.RFC3339)
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String()
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64)
+	case reflect.Slice, reflect.Array:
+		if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+			return string(v.Bytes())
+		}
+		values := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			item := indirect(v.Index(i))
+			if isComposite(item) {
+				return marshalCell(v)
+			}
+			values = append(values, formatCell(item))
+		}
+		return strings.Join(values, listSeparator)
+	default:
+		return marshalCell(v)
+	}
+}
+
+// marshalCell 将值输出为紧凑的 JSON
+func marshalCell(v reflect.Value) string {
+	raw, err := json.Marshal(v.Interface())
+	if err != nil {
+		return fmt.Sprintf("%v", v.Interface())
+	}
+	return string(raw)
+}
+
+// isComposite 判断值是否为需要以 JSON 输出的结构体、map 或嵌套切片
+func isComposite(v reflect.Value) bool {
+	if !v.IsValid() || v.Type() == timeType {
+		return false
+	}
+	switch v.Kind() {
+	case reflect.Struct, reflect.Map, reflect.Slice, reflect.Array:
+		return true
+	}
+	return false
+}
+
+// indirect 解引用指针和接口，nil 时返回无效值
+func indirect(v reflect.Value) reflect.Value {
+	for v.IsValid() && (v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface) {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	return v
+}
+
+// rowStructType 返回按结构体展开的行类型，行为 nil 指针时从切片的元素类型推断
+// 不是结构体时返回 nil
+func rowStructType(data, item reflect.Value) reflect.Type {
+	var t reflect.Type
+	switch {
+	case item.IsValid():
+		t = item.Type()
+	case data.Kind() == reflect.Slice || data.Kind() == reflect.Array:
+		t = data.Type().Elem()
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+	default:
+		return nil
+	}
+	if t.Kind() != reflect.Struct || t == timeType {
+		return nil
+	}
+	return t
+}
+
+// joinKey 以 "." 连接嵌套字段的列名
+func joinKey(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,139 @@
+package output
+
+import (
+	"testing"
+	"time"
+
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+)
+
+type delimitedOrigin struct {
+	Address string `json:"address" table:"源站"`
+	Port    int    `json:"port" table:"端口"`
+}
+
+type delimitedResult struct {
+	TaskID    string           `json:"task_id" table:"任务ID"`
+	URLs      []string         `json:"urls" table:"URL列表"`
+	Origin    *delimitedOrigin `json:"origin" table:"源站"`
+	Ratio     float64          `json:"ratio"`
+	CreatedAt time.Time        `json:"created_at" table:"创建时间"`
+	Secret    string           `json:"-" table:"密钥"`
+}
+
+var delimitedResults = []delimitedResult{
+	{
+		TaskID:    "task-1",
+		URLs:      []string{"https://a.example.com/1", "https://a.example.com/2"},
+		Origin:    &delimitedOrigin{Address: "1.1.1.1", Port: 443},
+		Ratio:     0.000001,
+		CreatedAt: time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC),
+		Secret:    "secret",
+	},
+	{TaskID: "task-2,x"},
+}
+
+// withDelimitedOptions 设置 CSV、TSV 选项并在测试结束后恢复默认值
+func withDelimitedOptions(t *testing.T, noHeaders bool, headerStyle string) {
+	t.Helper()
+	SetNoHeaders(noHeaders)
+	if err := SetHeaderStyle(headerStyle); err != nil {
+		t.Fatalf("设置表头样式失败: %v", err)
+	}
+	t.Cleanup(func() {
+		SetNoHeaders(false)
+		SetHeaderStyle("")
+	})
+}
+
+func TestPrintCSV(t *testing.T) {
+	formatter := NewFormatter("csv")
+	if formatter.IsHumanReadable() || formatter.IsStructured() || !formatter.IsDelimited() {
+		t.Error("csv 应为分隔符格式")
+	}
+
+	output := captureOutput(func() {
+		if err := formatter.Print(delimitedResults); err != nil {
+			t.Errorf("输出失败: %v", err)
+		}
+	})
+	expected := "任务ID,URL列表,源站.源站,源站.端口,ratio,创建时间\n" +
+		"task-1,https://a.example.com/1;https://a.example.com/2,1.1.1.1,443,0.000001,2026-10-15T08:00:00Z\n" +
+		"\"task-2,x\",,,,0,\n"
+	if output != expected {
+		t.Errorf("CSV 输出错误:\n%s", output)
+	}
+}
+
+func TestPrintTSVJSONHeaders(t *testing.T) {
+	withDelimitedOptions(t, false, "json")
+	output := captureOutput(func() {
+		if err := NewFormatter("tsv").Print(delimitedResults[:1]); err != nil {
+			t.Errorf("输出失败: %v", err)
+		}
+	})
+	expected := "task_id\turls\torigin.address\torigin.port\tratio\tcreated_at\n" +
+		"task-1\thttps://a.example.com/1;https://a.example.com/2\t1.1.1.1\t443\t0.000001\t2026-10-15T08:00:00Z\n"
+	if output != expected {
+		t.Errorf("TSV 输出错误:\n%s", output)
+	}
+}
+
+func TestPrintCSVNoHeaders(t *testing.T) {
+	withDelimitedOptions(t, true, "")
+	output := captureOutput(func() {
+		if err := NewFormatter("csv").Print([]delimitedOrigin{{Address: "1.1.1.1", Port: 80}}); err != nil {
+			t.Errorf("输出失败: %v", err)
+		}
+	})
+	if output != "1.1.1.1,80\n" {
+		t.Errorf("--no-headers 时不应输出表头，实际输出: %q", output)
+	}
+}
+
+func TestPrintCSVEmptySlice(t *testing.T) {
+	output := captureOutput(func() {
+		if err := NewFormatter("csv").Print([]delimitedOrigin{}); err != nil {
+			t.Errorf("输出失败: %v", err)
+		}
+	})
+	if output != "源站,端口\n" {
+		t.Errorf("空列表应只输出表头，实际输出: %q", output)
+	}
+}
+
+func TestPrintCSVMaps(t *testing.T) {
+	data := []map[string]interface{}{
+		{"name": "a", "tags": map[string]interface{}{"env": "prod"}},
+		{"name": "b", "count": 2},
+	}
+	output := captureOutput(func() {
+		if err := NewFormatter("csv").Print(data); err != nil {
+			t.Errorf("输出失败: %v", err)
+		}
+	})
+	expected := "count,name,tags.env\n,a,prod\n2,b,\n"
+	if output != expected {
+		t.Errorf("map 的列应按字段名排序，实际输出:\n%s", output)
+	}
+}
+
+func TestQueryCSV(t *testing.T) {
+	withQuery(t, "[?status=='失败'].{id: id, total: total}")
+	output := captureOutput(func() {
+		if err := NewFormatter("csv").Print(queryTasks); err != nil {
+			t.Errorf("输出失败: %v", err)
+		}
+	})
+	expected := "id,total\ntask-1,1700000000\ntask-3,3\n"
+	if output != expected {
+		t.Errorf("查询结果的 CSV 输出错误:\n%s", output)
+	}
+}
+
+func TestSetHeaderStyleInvalid(t *testing.T) {
+	err := SetHeaderStyle("xml")
+	if hwErrors.ExitCode(err) != hwErrors.ExitCodeValidation {
+		t.Errorf("不支持的表头样式应返回参数错误，实际为 %v", err)
+	}
+}
@@ -3,11 +3,14 @@ package output
 import (
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"reflect"
 	"strings"
 	"text/tabwriter"
 
+	"github.com/jmespath/go-jmespath"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
 	"gopkg.in/yaml.v3"
 )
 
@@ -19,12 +22,18 @@ const (
 	FormatJSON  Format = "json"
 	FormatYAML  Format = "yaml"
 	FormatText  Format = "text"
+	FormatCSV   Format = "csv"
+	FormatTSV   Format = "tsv"
 )
 
 // Formatter 输出格式化器
 type Formatter struct {
 	format Format
 	writer *tabwriter.Writer
+	query  *jmespath.JMESPath // 输出前执行的 JMESPath 查询，为 nil 时输出原始数据
+
+	noHeaders   bool   // CSV、TSV 输出是否省略表头
+	headerStyle string // CSV、TSV 表头使用的字段名来源
 }
 
 // NewFormatter 创建新的格式化器
@@ -32,12 +41,36 @@ func NewFormatter(format string) *Formatter {
 	f := &Formatter{
 		format: Format(format),
 		writer: tabwriter.NewWriter(os.Stdout, 0, 8, 2, ' ', 0),
+		query:  defaultQuery,
+
+		noHeaders:   defaultNoHeaders,
+		headerStyle: defaultHeaderStyle,
 	}
 	return f
 }
 
-// Print 根据格式输出数据
+// Print 根据格式输出数据，指定了 JMESPath 查询时输出查询结果
 func (f *Formatter) Print(data interface{}) error {
+	if f.query != nil {
+		result, err := applyQuery(f.query, data)
+		if err != nil {
+			return err
+		}
+		switch f.format {
+		case FormatJSON:
+			return f.printJSON(result)
+		case FormatYAML:
+			return f.printYAML(result)
+		case FormatText:
+			printQueryText(os.Stdout, result)
+			return nil
+		case FormatCSV, FormatTSV:
+			return f.printDelimited(result)
+		default:
+			return f.printQueryTable(result)
+		}
+	}
+
 	switch f.format {
 	case FormatJSON:
 		return f.printJSON(data)
@@ -47,6 +80,8 @@ func (f *Formatter) Print(data interface{}) error {
 		return f.printTable(data)
 	case FormatText:
 		return f.printText(data)
+	case FormatCSV, FormatTSV:
+		return f.printDelimited(data)
 	default:
 		return f.printTable(data)
 	}
@@ -178,22 +213,65 @@ func (f *Formatter) printText(data interface{}) error {
 	return nil
 }
 
+// messageWriter 返回提示消息的输出目标
+// json、yaml、csv、tsv 格式或指定了查询时写入标准错误，保证标准输出只包含可解析的结果
+func (f *Formatter) messageWriter() io.Writer {
+	if f.IsStructured() || f.IsDelimited() || f.query != nil {
+		return os.Stderr
+	}
+	return os.Stdout
+}
+
 // PrintSuccess 打印成功消息
 func (f *Formatter) PrintSuccess(message string) {
-	fmt.Printf("✅ %s\n", message)
+	fmt.Fprintf(f.messageWriter(), "✅ %s\n", message)
+}
+
+// IsHumanReadable 判断是否输出面向人阅读的表格或文本
+// 指定 JMESPath 查询时只输出查询结果，命令不应再输出额外的提示和汇总信息
+func (f *Formatter) IsHumanReadable() bool {
+	return (f.format == FormatTable || f.format == FormatText) && f.query == nil
 }
 
-// PrintError 打印错误消息
+// IsStructured 判断是否为 json、yaml 等供程序解析的输出格式
+func (f *Formatter) IsStructured() bool {
+	return f.format == FormatJSON || f.format == FormatYAML
+}
+
+// PrintError 打印错误消息，始终写入标准错误
+// json、yaml 格式下不输出，错误由 PrintErrorDocument 以结构化文档写入标准错误
+// 命令返回的错误由 Execute 统一输出，命令中无需再调用
 func (f *Formatter) PrintError(message string) {
-	fmt.Printf("❌ %s\n", message)
+	if f.IsStructured() {
+		return
+	}
+	fmt.Fprintf(os.Stderr, "❌ %s\n", message)
+}
+
+// PrintErrorDocument 将错误按当前格式输出为结构化文档，字段与 HuaweiCloudError 的 JSON 标签一致
+// 非结构化格式下输出为 JSON
+func (f *Formatter) PrintErrorDocument(w io.Writer, err error) error {
+	hwErr := hwErrors.AsHuaweiCloudError(err)
+	if hwErr == nil {
+		return nil
+	}
+
+	if f.format == FormatYAML {
+		encoder := yaml.NewEncoder(w)
+		defer encoder.Close()
+		return encoder.Encode(hwErr)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(hwErr)
 }
 
 // PrintWarning 打印警告消息
 func (f *Formatter) PrintWarning(message string) {
-	fmt.Printf("⚠️  %s\n", message)
+	fmt.Fprintf(f.messageWriter(), "⚠️  %s\n", message)
 }
 
 // PrintInfo 打印信息消息
 func (f *Formatter) PrintInfo(message string) {
-	fmt.Printf("ℹ️  %s\n", message)
+	fmt.Fprintf(f.messageWriter(), "ℹ️  %s\n", message)
 }
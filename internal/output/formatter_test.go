@@ -2,10 +2,14 @@ package output
 
 import (
 	"bytes"
+	"encoding/json"
+	"fmt"
 	"io"
 	"os"
 	"strings"
 	"testing"
+
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
 )
 
 func captureOutput(fn func()) string {
@@ -23,6 +27,22 @@ func captureOutput(fn func()) string {
 	return buf.String()
 }
 
+// captureStderr 捕获标准错误的输出
+func captureStderr(fn func()) string {
+	old := os.Stderr
+	r, w, _ := os.Pipe()
+	os.Stderr = w
+
+	fn()
+
+	w.Close()
+	os.Stderr = old
+
+	var buf bytes.Buffer
+	io.Copy(&buf, r)
+	return buf.String()
+}
+
 func TestNewFormatter(t *testing.T) {
 	formatter := NewFormatter("json")
 	if formatter == nil {
@@ -99,13 +119,19 @@ func TestFormatter_PrintSuccess(t *testing.T) {
 func TestFormatter_PrintError(t *testing.T) {
 	formatter := NewFormatter("text")
 
-	output := captureOutput(func() {
-		formatter.PrintError("操作失败")
+	var output string
+	stdout := captureOutput(func() {
+		output = captureStderr(func() {
+			formatter.PrintError("操作失败")
+		})
 	})
 
 	if !strings.Contains(output, "❌") || !strings.Contains(output, "操作失败") {
 		t.Errorf("错误消息输出不正确，实际输出: %s", output)
 	}
+	if stdout != "" {
+		t.Errorf("错误消息不应写入标准输出，实际输出: %q", stdout)
+	}
 }
 
 func TestFormatter_PrintWarning(t *testing.T) {
@@ -179,3 +205,58 @@ func TestFormatter_ComplexData(t *testing.T) {
 		t.Error("复杂数据结构输出应该包含测试数据")
 	}
 }
+
+func TestFormatter_PrintErrorStructured(t *testing.T) {
+	for _, format := range []string{"json", "yaml"} {
+		output := captureOutput(func() {
+			NewFormatter(format).PrintError("操作失败")
+		})
+		if output != "" {
+			t.Errorf("%s 格式下不应向标准输出打印错误，实际输出: %q", format, output)
+		}
+	}
+}
+
+func TestFormatter_PrintErrorDocument(t *testing.T) {
+	err := &hwErrors.HuaweiCloudError{
+		Type:       hwErrors.ErrorTypeAuth,
+		Code:       "APIGW.0301",
+		Message:    "认证失败",
+		RequestID:  "req-1",
+		StatusCode: 401,
+		Hint:       "检查 AK/SK",
+	}
+
+	var buf bytes.Buffer
+	if e := NewFormatter("json").PrintErrorDocument(&buf, fmt.Errorf("查询失败: %w", err)); e != nil {
+		t.Fatalf("输出错误文档失败: %v", e)
+	}
+	var doc map[string]interface{}
+	if e := json.Unmarshal(buf.Bytes(), &doc); e != nil {
+		t.Fatalf("错误文档应为合法 JSON: %v, 输出: %s", e, buf.String())
+	}
+	if doc["type"] != "AuthenticationError" || doc["code"] != "APIGW.0301" || doc["request_id"] != "req-1" ||
+		doc["status_code"] != float64(401) || doc["retryable"] != false || doc["hint"] != "检查 AK/SK" {
+		t.Errorf("错误文档字段错误: %v", doc)
+	}
+
+	buf.Reset()
+	if e := NewFormatter("yaml").PrintErrorDocument(&buf, fmt.Errorf("boom")); e != nil {
+		t.Fatalf("输出错误文档失败: %v", e)
+	}
+	if !strings.Contains(buf.String(), "type: UnknownError") || !strings.Contains(buf.String(), "message: boom") {
+		t.Errorf("YAML 错误文档错误: %s", buf.String())
+	}
+}
+
+func TestFormatter_MessagesStructured(t *testing.T) {
+	formatter := NewFormatter("json")
+	output := captureOutput(func() {
+		formatter.PrintSuccess("操作成功")
+		formatter.PrintWarning("警告信息")
+		formatter.PrintInfo("提示信息")
+	})
+	if output != "" {
+		t.Errorf("json 格式下提示消息不应写入标准输出，实际输出: %q", output)
+	}
+}
@@ -0,0 +1,151 @@
+package output
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jmespath/go-jmespath"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+)
+
+// defaultQuery 通过 --query 指定的 JMESPath 表达式，NewFormatter 创建的格式化器都会使用
+var defaultQuery *jmespath.JMESPath
+
+// SetQuery 设置输出前执行的 JMESPath 表达式，表达式为空时清除
+func SetQuery(expr string) error {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		defaultQuery = nil
+		return nil
+	}
+
+	compiled, err := jmespath.Compile(expr)
+	if err != nil {
+		return hwErrors.NewValidationError(fmt.Sprintf("--query 表达式错误: %v", err))
+	}
+	defaultQuery = compiled
+	return nil
+}
+
+// HasQuery 判断是否指定了 JMESPath 查询
+func (f *Formatter) HasQuery() bool {
+	return f.query != nil
+}
+
+// applyQuery 将数据转换为与 JSON 输出相同的通用结构后执行查询，表达式中使用 JSON 字段名
+func applyQuery(query *jmespath.JMESPath, data interface{}) (interface{}, error) {
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+
+	result, err := query.Search(generic)
+	if err != nil {
+		return nil, hwErrors.NewValidationError(fmt.Sprintf("执行 --query 失败: %v", err))
+	}
+	return result, nil
+}
+
+// printQueryText 以文本形式输出查询结果，便于脚本处理
+// 数组每个元素一行，对象按字段名排序后以制表符分隔各字段的值
+func printQueryText(w io.Writer, data interface{}) {
+	switch v := data.(type) {
+	case nil:
+		return
+	case []interface{}:
+		for _, item := range v {
+			printQueryText(w, item)
+		}
+	case map[string]interface{}:
+		keys := sortedKeys(v)
+		values := make([]string, 0, len(keys))
+		for _, key := range keys {
+			values = append(values, formatQueryValue(v[key]))
+		}
+		fmt.Fprintln(w, strings.Join(values, "\t"))
+	default:
+		fmt.Fprintln(w, formatQueryValue(v))
+	}
+}
+
+// printQueryTable 以表格形式输出查询结果，对象数组的列为所有对象字段名的并集
+func (f *Formatter) printQueryTable(data interface{}) error {
+	switch v := data.(type) {
+	case []interface{}:
+		if len(v) == 0 {
+			fmt.Println("No data found.")
+			return nil
+		}
+		columns := map[string]bool{}
+		for _, item := range v {
+			object, ok := item.(map[string]interface{})
+			if !ok {
+				printQueryText(os.Stdout, v)
+				return nil
+			}
+			for key := range object {
+				columns[key] = true
+			}
+		}
+
+		headers := sortedKeys(columns)
+		fmt.Fprintln(f.writer, strings.Join(headers, "\t"))
+		for _, item := range v {
+			object := item.(map[string]interface{})
+			row := make([]string, len(headers))
+			for i, key := range headers {
+				row[i] = formatQueryValue(object[key])
+			}
+			fmt.Fprintln(f.writer, strings.Join(row, "\t"))
+		}
+		return f.writer.Flush()
+	case map[string]interface{}:
+		fmt.Fprintln(f.writer, "KEY\tVALUE")
+		for _, key := range sortedKeys(v) {
+			fmt.Fprintf(f.writer, "%s\t%s\n", key, formatQueryValue(v[key]))
+		}
+		return f.writer.Flush()
+	default:
+		printQueryText(os.Stdout, v)
+		return nil
+	}
+}
+
+// formatQueryValue 格式化单个值，数字不使用科学计数法，嵌套结构输出为紧凑的 JSON
+func formatQueryValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		raw, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(raw)
+	}
+}
+
+// sortedKeys 返回按字母顺序排列的 map 键
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,87 @@
+package output
+
+import (
+	"strings"
+	"testing"
+
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
+)
+
+type queryTask struct {
+	ID     string `json:"id" table:"任务ID"`
+	Status string `json:"status" table:"状态"`
+	Total  int64  `json:"total" table:"总数"`
+}
+
+var queryTasks = []queryTask{
+	{ID: "task-1", Status: "失败", Total: 1700000000},
+	{ID: "task-2", Status: "已完成", Total: 2},
+	{ID: "task-3", Status: "失败", Total: 3},
+}
+
+// withQuery 设置查询表达式并在测试结束后清除
+func withQuery(t *testing.T, expr string) {
+	t.Helper()
+	if err := SetQuery(expr); err != nil {
+		t.Fatalf("设置查询失败: %v", err)
+	}
+	t.Cleanup(func() { SetQuery("") })
+}
+
+func TestSetQueryInvalid(t *testing.T) {
+	err := SetQuery("[?status==")
+	if hwErrors.ExitCode(err) != hwErrors.ExitCodeValidation {
+		t.Errorf("表达式错误时应返回参数错误，实际为 %v", err)
+	}
+	if NewFormatter("json").HasQuery() {
+		t.Error("表达式错误时不应设置查询")
+	}
+}
+
+func TestQueryText(t *testing.T) {
+	withQuery(t, "[?status=='失败'].id")
+	formatter := NewFormatter("text")
+	if formatter.IsHumanReadable() {
+		t.Error("指定查询时不应按人工阅读格式输出额外信息")
+	}
+
+	output := captureOutput(func() {
+		if err := formatter.Print(queryTasks); err != nil {
+			t.Errorf("输出失败: %v", err)
+		}
+	})
+	if output != "task-1\ntask-3\n" {
+		t.Errorf("文本格式应每行输出一个值，实际输出: %q", output)
+	}
+}
+
+func TestQueryTable(t *testing.T) {
+	withQuery(t, "[].{id: id, total: total}")
+	output := captureOutput(func() {
+		NewFormatter("table").Print(queryTasks)
+	})
+	lines := strings.Split(strings.TrimSpace(output), "\n")
+	if len(lines) != 4 || !strings.HasPrefix(lines[0], "id") || !strings.Contains(lines[1], "1700000000") {
+		t.Errorf("表格输出错误: %q", output)
+	}
+}
+
+func TestQueryJSON(t *testing.T) {
+	withQuery(t, "length([?status=='失败'])")
+	output := captureOutput(func() {
+		NewFormatter("json").Print(queryTasks)
+	})
+	if strings.TrimSpace(output) != "2" {
+		t.Errorf("JSON 输出错误: %q", output)
+	}
+}
+
+func TestQueryMessagesToStderr(t *testing.T) {
+	withQuery(t, "[].id")
+	output := captureOutput(func() {
+		NewFormatter("table").PrintSuccess("操作成功")
+	})
+	if output != "" {
+		t.Errorf("指定查询时提示消息不应写入标准输出: %q", output)
+	}
+}
@@ -2,12 +2,14 @@ package retry
 
 import (
 	"context"
+	stderrors "errors"
 	"fmt"
 	"math"
 	"math/rand"
 	"time"
 
 	"github.com/ygqygq2/hwcctl/internal/errors"
+	"github.com/ygqygq2/hwcctl/internal/logx"
 )
 
 // Strategy 重试策略
@@ -36,8 +38,6 @@ type Config struct {
 	Multiplier float64
 	// 随机化因子（0-1，用于添加抖动）
 	Jitter float64
-	// 是否启用调试日志
-	Debug bool
 }
 
 // DefaultConfig 默认重试配置
@@ -49,7 +49,6 @@ func DefaultConfig() *Config {
 		MaxDelay:    30 * time.Second,
 		Multiplier:  2.0,
 		Jitter:      0.1,
-		Debug:       false,
 	}
 }
 
@@ -77,15 +76,13 @@ func (r *Retryer) Do(ctx context.Context, fn RetryableFunc) error {
 	var lastErr error
 
 	for attempt := 1; attempt <= r.config.MaxAttempts; attempt++ {
-		if r.config.Debug {
-			fmt.Printf("[DEBUG] 重试尝试 %d/%d\n", attempt, r.config.MaxAttempts)
-		}
+		logx.Debug("重试尝试", "attempt", attempt, "max_attempts", r.config.MaxAttempts)
 
 		// 执行函数
 		err := fn()
 		if err == nil {
-			if attempt > 1 && r.config.Debug {
-				fmt.Printf("[INFO] 重试成功，尝试次数: %d\n", attempt)
+			if attempt > 1 {
+				logx.Info("重试成功", "attempt", attempt)
 			}
 			return nil
 		}
@@ -93,11 +90,10 @@ func (r *Retryer) Do(ctx context.Context, fn RetryableFunc) error {
 		lastErr = err
 
 		// 检查是否是华为云错误且不可重试
-		if hwErr, ok := err.(*errors.HuaweiCloudError); ok {
+		var hwErr *errors.HuaweiCloudError
+		if stderrors.As(err, &hwErr) {
 			if !hwErr.IsRetryable() {
-				if r.config.Debug {
-					fmt.Printf("[DEBUG] 错误不可重试: %v\n", err)
-				}
+				logx.Debug("错误不可重试", logx.KeyError, err)
 				return err
 			}
 		}
@@ -110,9 +106,7 @@ func (r *Retryer) Do(ctx context.Context, fn RetryableFunc) error {
 		// 计算延迟时间
 		delay := r.calculateDelay(attempt)
 
-		if r.config.Debug {
-			fmt.Printf("[DEBUG] 重试失败: %v, 等待 %v 后重试\n", err, delay)
-		}
+		logx.Debug("重试失败，等待后重试", logx.KeyError, err, "delay", delay)
 
 		// 检查上下文是否被取消
 		select {
@@ -137,15 +131,13 @@ func (r *Retryer) DoWithResult(ctx context.Context, fn func() (interface{}, erro
 	var result interface{}
 
 	for attempt := 1; attempt <= r.config.MaxAttempts; attempt++ {
-		if r.config.Debug {
-			fmt.Printf("[DEBUG] 重试尝试 %d/%d\n", attempt, r.config.MaxAttempts)
-		}
+		logx.Debug("重试尝试", "attempt", attempt, "max_attempts", r.config.MaxAttempts)
 
 		// 执行函数
 		res, err := fn()
 		if err == nil {
-			if attempt > 1 && r.config.Debug {
-				fmt.Printf("[INFO] 重试成功，尝试次数: %d\n", attempt)
+			if attempt > 1 {
+				logx.Info("重试成功", "attempt", attempt)
 			}
 			return res, nil
 		}
@@ -154,11 +146,10 @@ func (r *Retryer) DoWithResult(ctx context.Context, fn func() (interface{}, erro
 		result = res
 
 		// 检查是否是华为云错误且不可重试
-		if hwErr, ok := err.(*errors.HuaweiCloudError); ok {
+		var hwErr *errors.HuaweiCloudError
+		if stderrors.As(err, &hwErr) {
 			if !hwErr.IsRetryable() {
-				if r.config.Debug {
-					fmt.Printf("[DEBUG] 错误不可重试: %v\n", err)
-				}
+				logx.Debug("错误不可重试", logx.KeyError, err)
 				return result, err
 			}
 		}
@@ -171,9 +162,7 @@ func (r *Retryer) DoWithResult(ctx context.Context, fn func() (interface{}, erro
 		// 计算延迟时间
 		delay := r.calculateDelay(attempt)
 
-		if r.config.Debug {
-			fmt.Printf("[DEBUG] 重试失败: %v, 等待 %v 后重试\n", err, delay)
-		}
+		logx.Debug("重试失败，等待后重试", logx.KeyError, err, "delay", delay)
 
 		// 检查上下文是否被取消
 		select {
@@ -227,7 +216,8 @@ func (r *Retryer) calculateDelay(attempt int) time.Duration {
 
 // IsRetryable 检查错误是否可重试
 func IsRetryable(err error) bool {
-	if hwErr, ok := err.(*errors.HuaweiCloudError); ok {
+	var hwErr *errors.HuaweiCloudError
+	if stderrors.As(err, &hwErr) {
 		return hwErr.IsRetryable()
 	}
 
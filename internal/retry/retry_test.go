@@ -1,10 +1,15 @@
 package retry
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"os"
+	"strings"
 	"testing"
 	"time"
+
+	"github.com/ygqygq2/hwcctl/internal/logx"
 )
 
 func TestDefaultConfig(t *testing.T) {
@@ -87,6 +92,44 @@ func TestRetryer_Do_RetryableError(t *testing.T) {
 	}
 }
 
+func TestRetryer_DoLogging(t *testing.T) {
+	var logs bytes.Buffer
+	logx.SetOutput(&logs)
+	t.Cleanup(func() {
+		logx.SetOutput(os.Stderr)
+		logx.SetLevel("warn")
+	})
+
+	config := &Config{MaxAttempts: 2, Strategy: StrategyFixed, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+	run := func() error {
+		callCount := 0
+		return NewRetryer(config).Do(context.Background(), func() error {
+			callCount++
+			if callCount < 2 {
+				return errors.New("temporary error")
+			}
+			return nil
+		})
+	}
+
+	// 默认日志级别下不输出重试过程
+	if err := run(); err != nil {
+		t.Fatalf("期望最终成功，但得到错误: %v", err)
+	}
+	if logs.Len() != 0 {
+		t.Errorf("默认级别不应输出重试日志，实际为: %s", logs.String())
+	}
+
+	logx.SetLevel("debug")
+	if err := run(); err != nil {
+		t.Fatalf("期望最终成功，但得到错误: %v", err)
+	}
+	output := logs.String()
+	if !strings.Contains(output, "重试失败，等待后重试") || !strings.Contains(output, "重试成功") {
+		t.Errorf("调试级别应输出重试过程，实际为: %s", output)
+	}
+}
+
 func TestRetryer_DoWithResult_Success(t *testing.T) {
 	retryer := NewRetryer(DefaultConfig())
 	ctx := context.Background()
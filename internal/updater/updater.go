@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/ygqygq2/hwcctl/internal/logx"
+	"github.com/ygqygq2/hwcctl/internal/utils"
 )
 
 // Config 更新器配置
@@ -285,40 +286,16 @@ func (u *Updater) downloadAsset(asset *GitHubAsset) (string, error) {
 
 // copyWithProgress 带进度显示的复制
 func (u *Updater) copyWithProgress(dst io.Writer, src io.Reader, total int64) (int64, error) {
-	var written int64
-	buf := make([]byte, 32*1024) // 32KB 缓冲区
-
-	for {
-		nr, er := src.Read(buf)
-		if nr > 0 {
-			nw, ew := dst.Write(buf[0:nr])
-			if nw > 0 {
-				written += int64(nw)
-			}
-			if ew != nil {
-				return written, ew
-			}
-			if nr != nw {
-				return written, io.ErrShortWrite
-			}
-
-			// 显示进度
-			if total > 0 {
-				progress := float64(written) / float64(total) * 100
-				fmt.Printf("\r下载进度: %.1f%% (%.2f/%.2f MB)",
-					progress,
-					float64(written)/1024/1024,
-					float64(total)/1024/1024)
-			}
-		}
-		if er != nil {
-			if er != io.EOF {
-				return written, er
-			}
-			break
+	return utils.CopyWithProgress(dst, src, func(written int64) {
+		// 显示进度
+		if total > 0 {
+			progress := float64(written) / float64(total) * 100
+			fmt.Printf("\r下载进度: %.1f%% (%.2f/%.2f MB)",
+				progress,
+				float64(written)/1024/1024,
+				float64(total)/1024/1024)
 		}
-	}
-	return written, nil
+	})
 }
 
 // installUpdate 安装更新
@@ -0,0 +1,45 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FormatBytes 将字节数格式化为易读的字符串，使用 1024 进制，例如 1.50 MB
+func FormatBytes(n float64) string {
+	return formatWithUnits(n, 1024, []string{"B", "KB", "MB", "GB", "TB", "PB"})
+}
+
+// FormatBitRate 将比特率格式化为易读的字符串，使用 1000 进制，例如 12.30 Mbps
+func FormatBitRate(bps float64) string {
+	return formatWithUnits(bps, 1000, []string{"bps", "Kbps", "Mbps", "Gbps", "Tbps"})
+}
+
+// FormatCount 将次数格式化为易读的字符串，小于 1000 时原样输出，更大的数值使用 K/M/B/T 单位，例如 1.20M
+func FormatCount(n float64) string {
+	if n < 1000 && n > -1000 {
+		return strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	formatted := formatWithUnits(n, 1000, []string{"", "K", "M", "B", "T"})
+	return strings.ReplaceAll(formatted, " ", "")
+}
+
+// formatWithUnits 按 base 逐级换算单位，保留两位小数，最小单位时不保留小数
+func formatWithUnits(n, base float64, units []string) string {
+	sign := ""
+	if n < 0 {
+		sign = "-"
+		n = -n
+	}
+
+	i := 0
+	for n >= base && i < len(units)-1 {
+		n /= base
+		i++
+	}
+	if i == 0 {
+		return fmt.Sprintf("%s%.0f %s", sign, n, units[0])
+	}
+	return fmt.Sprintf("%s%.2f %s", sign, n, units[i])
+}
@@ -0,0 +1,47 @@
+package utils
+
+import "testing"
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1536, "1.50 KB"},
+		{5 * 1024 * 1024 * 1024, "5.00 GB"},
+		{-2048, "-2.00 KB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.input); got != tt.expected {
+			t.Errorf("FormatBytes(%v) = %s，期望 %s", tt.input, got, tt.expected)
+		}
+	}
+}
+
+func TestFormatBitRate(t *testing.T) {
+	if got := FormatBitRate(12300000); got != "12.30 Mbps" {
+		t.Errorf("FormatBitRate 结果错误: %s", got)
+	}
+	if got := FormatBitRate(800); got != "800 bps" {
+		t.Errorf("FormatBitRate 结果错误: %s", got)
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	tests := []struct {
+		input    float64
+		expected string
+	}{
+		{0, "0"},
+		{999, "999"},
+		{1200, "1.20K"},
+		{3450000, "3.45M"},
+	}
+	for _, tt := range tests {
+		if got := FormatCount(tt.input); got != tt.expected {
+			t.Errorf("FormatCount(%v) = %s，期望 %s", tt.input, got, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package utils
+
+import "io"
+
+// copyBufferSize 复制数据时使用的缓冲区大小
+const copyBufferSize = 32 * 1024 // 32KB 缓冲区
+
+// CopyWithProgress 将 src 复制到 dst，每写入一块数据后以累计写入的字节数调用 progress
+// progress 为 nil 时不报告进度
+func CopyWithProgress(dst io.Writer, src io.Reader, progress func(written int64)) (int64, error) {
+	var written int64
+	buf := make([]byte, copyBufferSize)
+
+	for {
+		nr, er := src.Read(buf)
+		if nr > 0 {
+			nw, ew := dst.Write(buf[0:nr])
+			if nw > 0 {
+				written += int64(nw)
+			}
+			if ew != nil {
+				return written, ew
+			}
+			if nr != nw {
+				return written, io.ErrShortWrite
+			}
+
+			if progress != nil {
+				progress(written)
+			}
+		}
+		if er != nil {
+			if er != io.EOF {
+				return written, er
+			}
+			break
+		}
+	}
+	return written, nil
+}
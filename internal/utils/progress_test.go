@@ -0,0 +1,30 @@
+package utils
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestCopyWithProgress(t *testing.T) {
+	data := strings.Repeat("a", copyBufferSize+10)
+	var dst bytes.Buffer
+	var reported []int64
+
+	written, err := CopyWithProgress(&dst, strings.NewReader(data), func(n int64) {
+		reported = append(reported, n)
+	})
+	if err != nil {
+		t.Fatalf("复制失败: %v", err)
+	}
+	if written != int64(len(data)) || dst.String() != data {
+		t.Errorf("期望写入 %d 字节，实际写入 %d 字节", len(data), written)
+	}
+	if len(reported) == 0 || reported[len(reported)-1] != written {
+		t.Errorf("进度回调错误: %v", reported)
+	}
+
+	if _, err := CopyWithProgress(&dst, strings.NewReader("x"), nil); err != nil {
+		t.Errorf("progress 为 nil 时不应失败: %v", err)
+	}
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// absoluteTimeLayouts 支持的绝对时间格式
+var absoluteTimeLayouts = []string{
+	time.RFC3339,
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// ParseTimeExpr 解析时间表达式，支持相对时间和绝对时间
+// 相对时间表示距 now 之前的时长，例如 30m、24h、7d；
+// 绝对时间支持 RFC3339、"2006-01-02 15:04:05"、"2006-01-02" 等格式（按本地时区解析）。
+func ParseTimeExpr(expr string, now time.Time) (time.Time, error) {
+	expr = strings.TrimSpace(expr)
+	if expr == "" {
+		return time.Time{}, fmt.Errorf("时间表达式不能为空")
+	}
+	if expr == "now" {
+		return now, nil
+	}
+
+	if d, err := ParseDuration(expr); err == nil {
+		return now.Add(-d), nil
+	}
+
+	for _, layout := range absoluteTimeLayouts {
+		if t, err := time.ParseInLocation(layout, expr, time.Local); err == nil {
+			return t, nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("无法解析时间 %q，支持相对时间（如 30m、24h、7d）或绝对时间（如 2006-01-02、2006-01-02 15:04:05、RFC3339）", expr)
+}
+
+// ParseDuration 解析时长，在 time.ParseDuration 的基础上支持以 d 为单位的天数，例如 7d、1d12h
+func ParseDuration(expr string) (time.Duration, error) {
+	expr = strings.TrimSpace(expr)
+	if idx := strings.Index(expr, "d"); idx > 0 {
+		days, err := strconv.Atoi(expr[:idx])
+		if err != nil {
+			return 0, fmt.Errorf("无效的时长 %q", expr)
+		}
+		d := time.Duration(days) * 24 * time.Hour
+		if rest := expr[idx+1:]; rest != "" {
+			extra, err := time.ParseDuration(rest)
+			if err != nil {
+				return 0, fmt.Errorf("无效的时长 %q", expr)
+			}
+			d += extra
+		}
+		return d, nil
+	}
+	return time.ParseDuration(expr)
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTimeExpr(t *testing.T) {
+	now := time.Date(2026, 10, 16, 12, 0, 0, 0, time.Local)
+
+	tests := []struct {
+		expr     string
+		expected time.Time
+	}{
+		{"now", now},
+		{"30m", now.Add(-30 * time.Minute)},
+		{"24h", now.Add(-24 * time.Hour)},
+		{"7d", now.AddDate(0, 0, -7)},
+		{"1d12h", now.Add(-36 * time.Hour)},
+		{"2026-10-15", time.Date(2026, 10, 15, 0, 0, 0, 0, time.Local)},
+		{"2026-10-15 08:30:00", time.Date(2026, 10, 15, 8, 30, 0, 0, time.Local)},
+		{"2026-10-15T08:30:00Z", time.Date(2026, 10, 15, 8, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			got, err := ParseTimeExpr(tt.expr, now)
+			if err != nil {
+				t.Fatalf("解析 %q 失败: %v", tt.expr, err)
+			}
+			if !got.Equal(tt.expected) {
+				t.Errorf("ParseTimeExpr(%q) = %v，期望 %v", tt.expr, got, tt.expected)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"", "yesterday", "2026/10/15", "xd"} {
+		if _, err := ParseTimeExpr(invalid, now); err == nil {
+			t.Errorf("期望 %q 解析失败", invalid)
+		}
+	}
+}
@@ -1,12 +1,12 @@
 package main
 
 import (
-	"fmt"
 	"os"
 	"runtime/debug"
 	"strings"
 
 	"github.com/ygqygq2/hwcctl/cmd"
+	hwErrors "github.com/ygqygq2/hwcctl/internal/errors"
 )
 
 var (
@@ -41,8 +41,8 @@ func main() {
 	// 设置版本信息
 	cmd.SetVersionInfo(version, buildTime, gitCommit)
 
+	// 错误信息已由 cmd.Execute 输出到标准错误，这里只按错误类型设置退出码
 	if err := cmd.Execute(); err != nil {
-		fmt.Fprintf(os.Stderr, "执行命令失败: %v\n", err)
-		os.Exit(1)
+		os.Exit(hwErrors.ExitCode(err))
 	}
 }